@@ -2,14 +2,11 @@ package main
 
 import (
 	"errors"
-	"fmt"
 	"log/slog"
 	"os"
 
 	flag "github.com/spf13/pflag"
 
-	"github.com/larsks/gobot/tools"
-
 	"github.com/larsks/kwctl/internal/commands"
 	"github.com/larsks/kwctl/internal/config"
 	"github.com/larsks/kwctl/pkg/radio"
@@ -17,24 +14,30 @@ import (
 
 var (
 	ctx config.Context
+
+	// Values of the global flags, before they are merged with the
+	// environment and the config file.
+	flagValues config.Config
 )
 
 func init() {
 	ctx.Logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
 		Level: slog.LevelWarn,
 	}))
-	flag.IntVarP(&ctx.Config.Bps, "bps", "b", tools.GetenvWithDefault("KWCTL_BPS", 9600), "serial port speed")
-	flag.CountVarP(&ctx.Config.Verbose, "verbose", "v", "increase logging verbosity")
-	flag.StringVarP(&ctx.Config.Vfo, "vfo", "", tools.GetenvWithDefault("KWCTL_VFO", "0"), "select vfo on which to operate")
-	flag.StringVarP(&ctx.Config.Device, "device", "d", tools.GetenvWithDefault("KWCTL_DEVICE", "/dev/ttyS0"), "serial device")
-	flag.BoolVarP(&ctx.Config.Pretty, "pretty", "p", tools.GetenvWithDefault("KWCTL_PRETTY", false), "pretty print output")
-	flag.BoolVarP(&ctx.Config.NoCheck, "no-check", "n", tools.GetenvWithDefault("KWCTL_NOCHECK", false), "Skip radio check")
+	config.AddFlags(flag.CommandLine, &flagValues)
 }
 
 func main() {
 	flag.SetInterspersed(false)
 	flag.Parse()
 
+	cfg, err := config.Resolve(flag.CommandLine, &flagValues, os.LookupEnv)
+	if err != nil {
+		ctx.Logger.Error("failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+	ctx.Config = cfg
+
 	// Initialize logger based on verbose flag
 	logLevel := slog.LevelWarn
 	if ctx.Config.Verbose >= 2 {
@@ -46,11 +49,15 @@ func main() {
 		Level: logLevel,
 	}))
 
+	if ctx.Config.Radio != "" {
+		ctx.Logger.Info("using radio profile", "radio", ctx.Config.Radio, "device", ctx.Config.Device)
+	}
+
 	// Parse command
 	args := flag.Args()
 	if len(args) == 0 {
 		ctx.Logger.Error("no command specified")
-		showHelp(os.Stderr)
+		commands.Help(os.Stderr)
 		os.Exit(1)
 	}
 
@@ -61,7 +68,21 @@ func main() {
 		var r *radio.Radio
 
 		if handler.NeedsRadio() {
-			r = radio.NewRadio(ctx.Config.Device, ctx.Config.Bps).WithLogger(ctx.Logger)
+			verify, err := radio.ParseVerifyMode(ctx.Config.Verify)
+			if err != nil {
+				ctx.Logger.Error("invalid configuration", "error", err)
+				os.Exit(1)
+			}
+
+			r = radio.NewRadio(ctx.Config.Device, ctx.Config.Bps).
+				WithLogger(ctx.Logger).
+				WithModel(ctx.Config.Model).
+				WithReadOnly(ctx.Config.ReadOnly).
+				WithVerify(verify)
+
+			if ctx.Config.DryRun {
+				r.WithDryRun(os.Stdout)
+			}
 
 			if err := r.Open(); err != nil {
 				ctx.Logger.Error("failed to open radio", "device", ctx.Config.Device, "error", err)
@@ -69,7 +90,7 @@ func main() {
 			}
 			defer r.Close() //nolint:errcheck
 
-			if !ctx.Config.NoCheck {
+			if !ctx.Config.NoCheck && !commands.SkipCheck(handler) {
 				if err := r.Check(); err != nil {
 					ctx.Logger.Error("radio check failed", "device", ctx.Config.Device, "error", err)
 					os.Exit(1)
@@ -86,18 +107,10 @@ func main() {
 			os.Exit(1)
 		}
 	} else if commandName == "help" {
-		showHelp(os.Stdout)
+		commands.Help(os.Stdout)
 	} else {
 		ctx.Logger.Error("no such command", "command", commandName)
-		showHelp(os.Stderr)
+		commands.Help(os.Stderr)
 		os.Exit(1)
 	}
 }
-
-//nolint:errcheck
-func showHelp(out *os.File) {
-	fmt.Fprintf(out, "Available commands:\n\n")
-	for _, command := range commands.List() {
-		fmt.Fprintf(out, "  %s\n", command)
-	}
-}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "empty line",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:     "simple words",
+			input:    "edit 90 --rxfreq 146.82",
+			expected: []string{"edit", "90", "--rxfreq", "146.82"},
+		},
+		{
+			name:     "extra whitespace",
+			input:    "  bands\t dual  ",
+			expected: []string{"bands", "dual"},
+		},
+		{
+			name:     "double quotes",
+			input:    `edit 90 --name "BAK BAY"`,
+			expected: []string{"edit", "90", "--name", "BAK BAY"},
+		},
+		{
+			name:     "single quotes",
+			input:    `edit 90 --name 'A "B"'`,
+			expected: []string{"edit", "90", "--name", `A "B"`},
+		},
+		{
+			name:     "escaped space",
+			input:    `edit 90 --name BAK\ BAY`,
+			expected: []string{"edit", "90", "--name", "BAK BAY"},
+		},
+		{
+			name:     "empty quoted word",
+			input:    `edit 90 --name ""`,
+			expected: []string{"edit", "90", "--name", ""},
+		},
+		{
+			name:     "comment line",
+			input:    "# configure repeaters",
+			expected: nil,
+		},
+		{
+			name:     "trailing comment",
+			input:    "vfo 0 # select band A",
+			expected: []string{"vfo", "0"},
+		},
+		{
+			name:     "hash inside word",
+			input:    "edit 90 --name A#1",
+			expected: []string{"edit", "90", "--name", "A#1"},
+		},
+		{
+			name:    "unterminated quote",
+			input:   `edit 90 --name "BAK`,
+			wantErr: true,
+		},
+		{
+			name:    "trailing backslash",
+			input:   `edit 90 \`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			have, err := SplitArgs(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SplitArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(have, tt.expected) {
+				t.Errorf("SplitArgs() = %q, expected %q", have, tt.expected)
+			}
+		})
+	}
+}
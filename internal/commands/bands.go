@@ -26,6 +26,14 @@ func (c *BandsCommand) NeedsRadio() bool {
 	return true
 }
 
+func (c *BandsCommand) MutatesState() bool {
+	return true
+}
+
+func (c *BandsCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
 //nolint:errcheck
 func (c *BandsCommand) Init() error {
 	c.flags = flag.NewFlagSet("bands", flag.ContinueOnError)
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/larsks/gobot/tools"
+	"github.com/larsks/kwctl/internal/config"
+	"github.com/larsks/kwctl/internal/formatters"
+	"github.com/larsks/kwctl/pkg/radio"
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+type (
+	CallCommand struct {
+		flags      *flag.FlagSet
+		radioFlags types.RadioFlagValues
+	}
+)
+
+func init() {
+	Register("call", &CallCommand{})
+}
+
+func (c *CallCommand) NeedsRadio() bool {
+	return true
+}
+
+func (c *CallCommand) MutatesState() bool {
+	return true
+}
+
+func (c *CallCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
+//nolint:errcheck
+func (c *CallCommand) Init() error {
+	c.flags = flag.NewFlagSet("call", flag.ContinueOnError)
+
+	// Add common radio setting flags
+	types.AddRadioSettingFlags(c.flags, &c.radioFlags)
+
+	c.flags.SetOutput(os.Stdout)
+	c.flags.Usage = func() {
+		fmt.Fprint(c.flags.Output(), tools.Unindent(`
+			Usage: kwctl call [options]
+
+			Show or program the call channel of the selected VFO.
+
+			Options:
+		`))
+		c.flags.PrintDefaults()
+	}
+	return nil
+}
+
+func (c *CallCommand) Run(r *radio.Radio, ctx config.Context, args []string) error {
+	if err := c.flags.Parse(args); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	call, err := r.GetCallChannel(ctx.Config.Vfo)
+	if err != nil {
+		return fmt.Errorf("failed to read call channel: %w", err)
+	}
+	oldCall := call
+
+	// Apply common radio settings
+	if err := types.ApplyRadioSettingFlags(c.flags, &c.radioFlags, &call); err != nil {
+		return fmt.Errorf("invalid settings: %w", err)
+	}
+
+	if call != oldCall {
+		if err := r.SetCallChannel(call); err != nil {
+			return fmt.Errorf("failed to set call channel: %w", err)
+		}
+		call, err = r.GetCallChannel(ctx.Config.Vfo)
+		if err != nil {
+			return fmt.Errorf("failed to read call channel: %w", err)
+		}
+	}
+
+	if ctx.Config.Pretty {
+		formatter := formatters.NewTableFormatter(formatters.HeadersFromStruct(types.DisplayCallChannel{}))
+		formatter.Update([][]string{call.Values()})
+		formatter.Render(nil)
+	} else {
+		fmt.Printf("%s\n", call)
+	}
+
+	return nil
+}
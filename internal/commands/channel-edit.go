@@ -4,7 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"strconv"
+	"strings"
 
 	flag "github.com/spf13/pflag"
 
@@ -17,13 +17,14 @@ import (
 
 type (
 	ChannelEditCommand struct {
-		flags       *flag.FlagSet
-		radioFlags  types.RadioFlagValues
-		channelName string
-		txFreq      int
-		txStep      int
-		clear       bool
-		srcChannel  int
+		flags        *flag.FlagSet
+		radioFlags   types.RadioFlagValues
+		filterValues types.ChannelFilterValues
+		channelName  string
+		txFreq       int
+		txStep       int
+		clear        bool
+		srcChannel   string
 	}
 )
 
@@ -35,6 +36,14 @@ func (c *ChannelEditCommand) NeedsRadio() bool {
 	return true
 }
 
+func (c *ChannelEditCommand) MutatesState() bool {
+	return true
+}
+
+func (c *ChannelEditCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
 //nolint:errcheck
 func (c *ChannelEditCommand) Init() error {
 	c.flags = flag.NewFlagSet("channel-edit", flag.ContinueOnError)
@@ -49,17 +58,29 @@ func (c *ChannelEditCommand) Init() error {
 	c.flags.Bool("lockout", false, "skip channel during scan")
 	c.flags.Bool("no-lockout", false, "don't skip channel during scan")
 	c.flags.BoolVarP(&c.clear, "clear", "", false, "clear channel")
-	c.flags.IntVarP(&c.srcChannel, "copy", "", -1, "copy data from another channel")
+	c.flags.StringVarP(&c.srcChannel, "copy", "", "", "copy data from another channel (number or name)")
+
+	// Add filters for bulk edits
+	types.AddChannelFilterFlags(c.flags, &c.filterValues, "match-")
 
 	c.flags.SetOutput(os.Stdout)
 	c.flags.Usage = func() {
 		fmt.Fprint(c.flags.Output(), tools.Unindent(`
 			Usage: kwctl channel-edit [options] <channel>
+			       kwctl channel-edit [options] <range>|<pattern>
 
 			Edit channel configuration.
 
 			Arguments:
-				channel    Channel number to edit (0-999)
+				channel    Channel number (0-999) or name of channel to edit
+				range      A range specification (e.g. "1-10", "1,5,10,15,20")
+				pattern    A channel name containing glob patterns (e.g. "BAK*")
+
+			When given a range or pattern, or any of the --match-* options, the
+			changes are applied to every channel in use that matches all of the
+			filters, and a summary of the changes is shown. Empty channels are
+			skipped. The --clear, --copy and --name options cannot be used when
+			editing multiple channels.
 
 			Options:
 			`))
@@ -77,9 +98,13 @@ func (c *ChannelEditCommand) Run(r *radio.Radio, ctx config.Context, args []stri
 		return fmt.Errorf("missing channel number")
 	}
 
-	channelNumber, err := strconv.Atoi(c.flags.Arg(0))
+	if c.isBulk() {
+		return c.runBulk(r, ctx, c.flags.Arg(0))
+	}
+
+	channelNumber, err := r.ResolveMemoryChannel(c.flags.Arg(0))
 	if err != nil {
-		return fmt.Errorf("invalid channel number")
+		return fmt.Errorf("invalid channel: %w", err)
 	}
 
 	if c.clear {
@@ -92,11 +117,16 @@ func (c *ChannelEditCommand) Run(r *radio.Radio, ctx config.Context, args []stri
 	var channel types.Channel
 	var oldChannel types.Channel
 
-	if c.srcChannel >= 0 {
+	if c.srcChannel != "" {
+		srcChannel, err := r.ResolveMemoryChannel(c.srcChannel)
+		if err != nil {
+			return fmt.Errorf("invalid source channel: %w", err)
+		}
+
 		oldChannel = types.Channel{Number: channelNumber}
-		channel, err = r.GetMemoryChannel(c.srcChannel)
+		channel, err = r.GetMemoryChannel(srcChannel)
 		if err != nil {
-			return fmt.Errorf("failed to read channel %03d: %w", c.srcChannel, err)
+			return fmt.Errorf("failed to read channel %03d: %w", srcChannel, err)
 		}
 		channel.Number = channelNumber
 	} else {
@@ -111,24 +141,9 @@ func (c *ChannelEditCommand) Run(r *radio.Radio, ctx config.Context, args []stri
 		oldChannel = channel
 	}
 
-	// Apply common radio settings
-	types.ApplyRadioSettingFlags(c.flags, &c.radioFlags, &channel)
-
-	// Apply channel-specific flags
-	c.flags.Visit(func(f *flag.Flag) {
-		switch f.Name {
-		case "txfreq":
-			channel.TxFreq = c.txFreq
-		case "txstep":
-			channel.TxStep = c.txStep
-		case "lockout":
-			channel.Lockout = 1
-		case "no-lockout":
-			channel.Lockout = 0
-		case "name":
-			channel.Name = c.channelName
-		}
-	})
+	if err := c.applyChanges(&channel); err != nil {
+		return fmt.Errorf("invalid settings for channel %03d: %w", channelNumber, err)
+	}
 
 	if channel == (types.Channel{Number: channelNumber}) {
 		return nil
@@ -146,7 +161,7 @@ func (c *ChannelEditCommand) Run(r *radio.Radio, ctx config.Context, args []stri
 	}
 
 	if ctx.Config.Pretty {
-		formatter := formatters.NewTableFormatter(formatters.HeadersFromStruct(types.Channel{}))
+		formatter := formatters.NewTableFormatter(formatters.HeadersFromStruct(types.DisplayChannel{}))
 		formatter.Update([][]string{channel.Values()})
 		formatter.Render(nil)
 	} else {
@@ -155,3 +170,123 @@ func (c *ChannelEditCommand) Run(r *radio.Radio, ctx config.Context, args []stri
 
 	return nil
 }
+
+// applyChanges applies the settings given on the command line to channel.
+func (c *ChannelEditCommand) applyChanges(channel *types.Channel) error {
+	// Common radio settings, plus the channel-specific flags
+	patch := types.RadioSettingsPatch(c.flags, &c.radioFlags)
+	c.flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "txfreq":
+			patch.TxFreq = &c.txFreq
+		case "txstep":
+			patch.TxStep = &c.txStep
+		case "lockout":
+			lockout := true
+			patch.Lockout = &lockout
+		case "no-lockout":
+			lockout := false
+			patch.Lockout = &lockout
+		case "name":
+			patch.Name = &c.channelName
+		}
+	})
+
+	return patch.Apply(channel)
+}
+
+// isBulk returns true if the command line selects multiple channels: a
+// range of more than one channel, a name pattern, or any filter option.
+func (c *ChannelEditCommand) isBulk() bool {
+	spec := c.flags.Arg(0)
+	if isRange(spec) && strings.ContainsAny(spec, ",-") {
+		return true
+	}
+	if strings.ContainsAny(spec, "*?[") {
+		return true
+	}
+
+	filtered := false
+	c.flags.Visit(func(f *flag.Flag) {
+		if strings.HasPrefix(f.Name, "match-") {
+			filtered = true
+		}
+	})
+	return filtered
+}
+
+// runBulk applies the same changes to every channel in use selected by spec
+// and the filter options.
+func (c *ChannelEditCommand) runBulk(r *radio.Radio, ctx config.Context, spec string) error {
+	for _, name := range []string{"clear", "copy", "name"} {
+		if c.flags.Changed(name) {
+			return fmt.Errorf("--%s cannot be used when editing multiple channels", name)
+		}
+	}
+
+	filter := types.ChannelFilter{Used: true}
+	if err := types.ApplyChannelFilterFlags(c.flags, &c.filterValues, "match-", &filter); err != nil {
+		return fmt.Errorf("invalid filter: %w", err)
+	}
+
+	var channels []types.Channel
+	if isRange(spec) {
+		for channelNumber, err := range tools.RangeIterator(spec) {
+			if err != nil {
+				return fmt.Errorf("invalid range: %w", err)
+			}
+			if channelNumber < 0 || channelNumber > radio.MaxChannel {
+				return fmt.Errorf("invalid range (channels must be between 0 and %d)", radio.MaxChannel)
+			}
+
+			ctx.Logger.Info("getting information for channel", "channel", channelNumber)
+			channel, err := r.GetMemoryChannel(channelNumber)
+			if err != nil {
+				if errors.Is(err, radio.ErrUnavailableCommand) {
+					continue
+				}
+				return fmt.Errorf("failed to read channel %03d: %w", channelNumber, err)
+			}
+			channels = append(channels, channel)
+		}
+	} else {
+		ctx.Logger.Info("searching for channels", "pattern", spec)
+		found, err := r.FindMemoryChannels(spec)
+		if err != nil {
+			return fmt.Errorf("failed to find channels: %w", err)
+		}
+		channels = found
+	}
+
+	matched, changed := 0, 0
+	for _, channel := range channels {
+		if !filter.Match(channel) {
+			continue
+		}
+		matched++
+
+		oldChannel := channel
+		if err := c.applyChanges(&channel); err != nil {
+			return fmt.Errorf("invalid settings for channel %03d (%d channels already changed): %w", channel.Number, changed, err)
+		}
+
+		diffs := oldChannel.Diff(channel)
+		if len(diffs) == 0 {
+			continue
+		}
+
+		ctx.Logger.Info("updating channel", "channel", channel.Number)
+		if err := r.SetMemoryChannel(channel); err != nil {
+			return fmt.Errorf("failed to set channel %d (%d channels already changed): %w", channel.Number, changed, err)
+		}
+		changed++
+
+		fmt.Printf("%03d [%-6s]\n", channel.Number, channel.Name)
+		for _, diff := range diffs {
+			fmt.Printf("    %s\n", diff)
+		}
+	}
+
+	fmt.Printf("Changed %d of %d matching channels.\n", changed, matched)
+	return nil
+}
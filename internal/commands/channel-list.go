@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	flag "github.com/spf13/pflag"
 
@@ -16,7 +17,10 @@ import (
 
 type (
 	ChannelListCommand struct {
-		flags *flag.FlagSet
+		flags        *flag.FlagSet
+		filterValues types.ChannelFilterValues
+		used         bool
+		empty        bool
 	}
 )
 
@@ -28,18 +32,35 @@ func (c *ChannelListCommand) NeedsRadio() bool {
 	return true
 }
 
+func (c *ChannelListCommand) MutatesState() bool {
+	return false
+}
+
+func (c *ChannelListCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
 //nolint:errcheck
 func (c *ChannelListCommand) Init() error {
 	c.flags = flag.NewFlagSet("channel-list", flag.ContinueOnError)
+	types.AddChannelFilterFlags(c.flags, &c.filterValues, "")
+	c.flags.BoolVarP(&c.used, "used", "", false, "list only channels that are in use")
+	c.flags.BoolVarP(&c.empty, "empty", "", false, "list only empty channels")
 	c.flags.SetOutput(os.Stdout)
 	c.flags.Usage = func() {
 		fmt.Fprint(c.flags.Output(), tools.Unindent(`
-			Usage: kwctl channel-list [options] <range> [<range> [...]]
+			Usage: kwctl channel-list [options] <range>|<name> [<range>|<name> [...]]
 
-			List a range of channels.
+			List a range of channels, or channels matching a name.
 
 			Arguments:
 				range      A range specification (e.g. "1", "1-10", "1,5,10,15,20")
+				name       A channel name; may contain glob patterns (e.g. "BAK*")
+
+			If any filter options are given, only channels in use that match all
+			of the filters are listed.
+
+			Options:
 			`))
 		c.flags.PrintDefaults()
 	}
@@ -52,6 +73,11 @@ func (c *ChannelListCommand) Run(r *radio.Radio, ctx config.Context, args []stri
 		return fmt.Errorf("command failed: %w", err)
 	}
 
+	filter := types.ChannelFilter{Used: c.used, Empty: c.empty}
+	if err := types.ApplyChannelFilterFlags(c.flags, &c.filterValues, "", &filter); err != nil {
+		return fmt.Errorf("invalid filter: %w", err)
+	}
+
 	var ranges []string
 	if c.flags.NArg() == 0 {
 		ranges = []string{"0-999"}
@@ -60,18 +86,56 @@ func (c *ChannelListCommand) Run(r *radio.Radio, ctx config.Context, args []stri
 	}
 
 	var formatter *formatters.TableFormatter
+	headers := formatters.HeadersFromStruct(types.DisplayChannel{})
 	if ctx.Config.Pretty {
-		formatter = formatters.NewTableFormatter(formatters.HeadersFromStruct(types.Channel{}))
+		formatter = formatters.NewTableFormatter(headers)
+	}
+
+	showChannel := func(channelNumber int, channel types.Channel) {
+		if !filter.Match(channel) {
+			return
+		}
+
+		if ctx.Config.Pretty {
+			switch {
+			case channel.RxFreq != 0:
+				formatter.Update([][]string{channel.Values()})
+			case filter.Empty:
+				// Empty channels are only shown when asked for, with
+				// just the channel number filled in.
+				row := make([]string, len(headers))
+				row[1] = fmt.Sprintf("%03d", channelNumber)
+				formatter.Update([][]string{row})
+			}
+		} else {
+			if channel.RxFreq == 0 {
+				fmt.Printf("[      ] %03d\n", channelNumber)
+			} else {
+				fmt.Printf("%s\n", channel)
+			}
+		}
 	}
 
 	for _, arg := range ranges {
+		if !isRange(arg) {
+			ctx.Logger.Info("searching for channels", "pattern", arg)
+			channels, err := r.FindMemoryChannels(arg)
+			if err != nil {
+				return fmt.Errorf("failed to list channels: %w", err)
+			}
+			for _, channel := range channels {
+				showChannel(channel.Number, channel)
+			}
+			continue
+		}
+
 		for channelNumber, err := range tools.RangeIterator(arg) {
 			ctx.Logger.Info("getting information for channel", "channel", channelNumber)
 			if err != nil {
 				return fmt.Errorf("invalid range: %w", err)
 			}
-			if channelNumber < 0 || channelNumber > 999 {
-				return fmt.Errorf("invalid range (channels must be between 0 and 999)")
+			if channelNumber < 0 || channelNumber > radio.MaxChannel {
+				return fmt.Errorf("invalid range (channels must be between 0 and %d)", radio.MaxChannel)
 			}
 
 			channel, err := r.GetMemoryChannel(channelNumber)
@@ -83,17 +147,7 @@ func (c *ChannelListCommand) Run(r *radio.Radio, ctx config.Context, args []stri
 				}
 			}
 
-			if ctx.Config.Pretty {
-				if channel.RxFreq != 0 {
-					formatter.Update([][]string{channel.Values()})
-				}
-			} else {
-				if channel.RxFreq == 0 {
-					fmt.Printf("[      ] %03d\n", channelNumber)
-				} else {
-					fmt.Printf("%s\n", channel)
-				}
-			}
+			showChannel(channelNumber, channel)
 		}
 	}
 
@@ -103,3 +157,9 @@ func (c *ChannelListCommand) Run(r *radio.Radio, ctx config.Context, args []stri
 
 	return nil
 }
+
+// isRange returns true if s looks like a range specification (e.g.
+// "1-10,20") rather than a channel name pattern.
+func isRange(s string) bool {
+	return strings.Trim(s, "0123456789,- ") == ""
+}
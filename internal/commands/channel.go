@@ -3,7 +3,6 @@ package commands
 import (
 	"fmt"
 	"os"
-	"strconv"
 
 	flag "github.com/spf13/pflag"
 
@@ -28,6 +27,14 @@ func (c *ChannelCommand) NeedsRadio() bool {
 	return true
 }
 
+func (c *ChannelCommand) MutatesState() bool {
+	return true
+}
+
+func (c *ChannelCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
 //nolint:errcheck
 func (c *ChannelCommand) Init() error {
 	c.flags = flag.NewFlagSet("channel", flag.ContinueOnError)
@@ -39,7 +46,7 @@ func (c *ChannelCommand) Init() error {
 			Get or set the current channel of the selected vfo.
 
 			Arguments:
-				channel    Channel number (0-999) or 'up'/'down' to increment/decrement
+				channel    Channel number (0-999) or name, or 'up'/'down' to increment/decrement
 		`))
 		c.flags.PrintDefaults()
 	}
@@ -70,9 +77,9 @@ func (c ChannelCommand) Run(r *radio.Radio, ctx config.Context, args []string) e
 				channelNum = max(channelNum-1, 0)
 			}
 		} else {
-			channelNum, err = strconv.Atoi(selected)
+			channelNum, err = r.ResolveMemoryChannel(selected)
 			if err != nil {
-				return fmt.Errorf("invalid channel number: %s", selected)
+				return fmt.Errorf("invalid channel: %w", err)
 			}
 		}
 
@@ -88,7 +95,7 @@ func (c ChannelCommand) Run(r *radio.Radio, ctx config.Context, args []string) e
 	}
 
 	if ctx.Config.Pretty {
-		formatter := formatters.NewTableFormatter(formatters.HeadersFromStruct(types.Channel{}))
+		formatter := formatters.NewTableFormatter(formatters.HeadersFromStruct(types.DisplayChannel{}))
 		formatter.Update([][]string{channel.Values()})
 		formatter.Render(nil)
 	} else {
@@ -1,7 +1,13 @@
 package commands
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"slices"
+
+	flag "github.com/spf13/pflag"
 
 	"github.com/larsks/kwctl/internal/config"
 	"github.com/larsks/kwctl/pkg/radio"
@@ -11,10 +17,28 @@ type (
 	Command interface {
 		Init() error
 		NeedsRadio() bool
+		// MutatesState returns true if the command can change the radio
+		// state (rather than only reading it).
+		MutatesState() bool
 		Run(r *radio.Radio, ctx config.Context, args []string) error
 	}
+
+	// FlagCommand is implemented by commands that expose their flag set.
+	// The interactive shell uses this to complete flag names.
+	FlagCommand interface {
+		Flags() *flag.FlagSet
+	}
+
+	// CheckCommand is implemented by commands that must be able to run
+	// when the radio does not respond to the check performed at startup
+	// (for example, because it is powered off).
+	CheckCommand interface {
+		SkipCheck() bool
+	}
 )
 
+var ErrNoSuchCommand = errors.New("no such command")
+
 var commands map[string]Command = make(map[string]Command)
 
 func Register(name string, command Command, aliases ...string) {
@@ -46,11 +70,55 @@ func Lookup(name string) Command {
 	return nil
 }
 
+// SkipCheck returns true if the radio check should not be performed before
+// running command.
+func SkipCheck(command Command) bool {
+	if c, ok := command.(CheckCommand); ok {
+		return c.SkipCheck()
+	}
+	return false
+}
+
 func List() []string {
 	var names []string
 	for name := range commands {
 		names = append(names, name)
 	}
 
+	slices.Sort(names)
 	return names
 }
+
+// Execute runs the command named by args[0] with the remaining arguments
+// against an already open radio. The command is re-initialized before it
+// runs so that flag values from a previous invocation in the same session
+// do not carry over.
+func Execute(r *radio.Radio, ctx config.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no command specified")
+	}
+
+	handler := Lookup(args[0])
+	if handler == nil {
+		return fmt.Errorf("%s: %w", args[0], ErrNoSuchCommand)
+	}
+
+	if err := handler.Init(); err != nil {
+		return fmt.Errorf("failed to initialize command %s: %w", args[0], err)
+	}
+
+	return handler.Run(r, ctx, args[1:])
+}
+
+//nolint:errcheck
+func Help(out io.Writer) {
+	fmt.Fprintf(out, "Available commands:\n\n")
+	for _, command := range List() {
+		marker := " "
+		if Lookup(command).MutatesState() {
+			marker = "*"
+		}
+		fmt.Fprintf(out, "  %s %s\n", marker, command)
+	}
+	fmt.Fprintf(out, "\nCommands marked with * can change the radio state.\n")
+}
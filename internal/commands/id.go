@@ -25,6 +25,14 @@ func (c *IDCommand) NeedsRadio() bool {
 	return true
 }
 
+func (c *IDCommand) MutatesState() bool {
+	return false
+}
+
+func (c *IDCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
 //nolint:errcheck
 func (c *IDCommand) Init() error {
 	c.flags = flag.NewFlagSet("id", flag.ContinueOnError)
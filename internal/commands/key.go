@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/larsks/gobot/tools"
+	"github.com/larsks/kwctl/internal/config"
+	"github.com/larsks/kwctl/pkg/radio"
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+type (
+	KeyCommand struct {
+		flags *flag.FlagSet
+		delay time.Duration
+	}
+)
+
+func init() {
+	Register("key", &KeyCommand{})
+}
+
+func (c *KeyCommand) NeedsRadio() bool {
+	return true
+}
+
+func (c *KeyCommand) MutatesState() bool {
+	return true
+}
+
+func (c *KeyCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
+//nolint:errcheck
+func (c *KeyCommand) Init() error {
+	c.flags = flag.NewFlagSet("key", flag.ContinueOnError)
+	c.flags.DurationVarP(&c.delay, "delay", "", 200*time.Millisecond, "time to wait between key presses")
+	c.flags.SetOutput(os.Stdout)
+	c.flags.Usage = func() {
+		fmt.Fprint(c.flags.Output(), tools.Unindent(`
+			Usage: kwctl key [options] <key> [<key>...]
+
+			Press a sequence of keys on the selected VFO.
+
+			Keys are UP, DOWN (or DW), VFO, MR, CALL and the digits 0-9. As on
+			the radio, up to three digits after MR select a memory channel, so
+			"kwctl key MR 1 4 6" (or "kwctl key MR 146") selects channel 146.
+			UP and DOWN act on the control band, so the selected VFO is made the
+			control band before they are pressed. The PF keys and the DTMF
+			keypad cannot be pressed remotely.
+
+			Options:
+		`))
+		c.flags.PrintDefaults()
+	}
+	return nil
+}
+
+func (c *KeyCommand) Run(r *radio.Radio, ctx config.Context, args []string) error {
+	if err := c.flags.Parse(args); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	if c.flags.NArg() == 0 {
+		return fmt.Errorf("key requires at least one key")
+	}
+
+	if c.delay < 0 {
+		return fmt.Errorf("delay cannot be negative")
+	}
+
+	keys, err := types.ParseKeySequence(c.flags.Args())
+	if err != nil {
+		return fmt.Errorf("invalid key sequence: %w", err)
+	}
+
+	ctx.Logger.Info("pressing keys", "vfo", ctx.Config.Vfo, "keys", keys)
+	if err := r.PressKeys(ctx.Config.Vfo, keys, c.delay); err != nil {
+		return fmt.Errorf("failed to press keys: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/larsks/gobot/tools"
+	"github.com/larsks/kwctl/internal/config"
+	"github.com/larsks/kwctl/pkg/radio"
+)
+
+type (
+	LockCommand struct {
+		flags *flag.FlagSet
+	}
+)
+
+func init() {
+	Register("lock", &LockCommand{})
+}
+
+func (c *LockCommand) NeedsRadio() bool {
+	return true
+}
+
+func (c *LockCommand) MutatesState() bool {
+	return true
+}
+
+// SkipCheck allows the lock to be changed without first identifying the
+// radio, like power.
+func (c *LockCommand) SkipCheck() bool {
+	return true
+}
+
+func (c *LockCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
+func (c *LockCommand) Init() error {
+	c.flags = flag.NewFlagSet("lock", flag.ContinueOnError)
+	c.flags.SetOutput(os.Stdout)
+	c.flags.Usage = func() {
+		//nolint:errcheck
+		fmt.Fprint(c.flags.Output(), tools.Unindent(`
+			Usage: kwctl lock [on|off]
+
+			Get or set the front panel key lock.
+		`))
+		c.flags.PrintDefaults()
+	}
+	return nil
+}
+
+func (c *LockCommand) Run(r *radio.Radio, ctx config.Context, args []string) error {
+	if err := c.flags.Parse(args); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	if c.flags.NArg() == 1 {
+		locked, err := parseOnOff(c.flags.Arg(0))
+		if err != nil {
+			return fmt.Errorf("failed to parse lock state: %w", err)
+		}
+		if err := r.SetLock(locked); err != nil {
+			return fmt.Errorf("failed to set lock: %w", err)
+		}
+	}
+
+	locked, err := r.GetLock()
+	if err != nil {
+		return fmt.Errorf("failed to get lock: %w", err)
+	}
+
+	fmt.Printf("%s\n", formatOnOff(locked))
+
+	return nil
+}
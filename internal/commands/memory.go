@@ -0,0 +1,354 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/larsks/gobot/tools"
+	"github.com/larsks/kwctl/internal/config"
+	"github.com/larsks/kwctl/internal/memory"
+	"github.com/larsks/kwctl/pkg/radio"
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+type (
+	MemoryCommand struct {
+		flags    *flag.FlagSet
+		sortBy   string
+		scratch  int
+		planOnly bool
+	}
+)
+
+func init() {
+	Register("memory", &MemoryCommand{})
+}
+
+func (c *MemoryCommand) NeedsRadio() bool {
+	return true
+}
+
+func (c *MemoryCommand) MutatesState() bool {
+	return true
+}
+
+func (c *MemoryCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
+//nolint:errcheck
+func (c *MemoryCommand) Init() error {
+	c.flags = flag.NewFlagSet("memory", flag.ContinueOnError)
+	c.flags.StringVarP(&c.sortBy, "by", "", memory.SortByFrequency, "sort key (freq or name)")
+	c.flags.IntVarP(&c.scratch, "scratch", "", memory.Empty, "empty channel to use as temporary storage (default: first empty channel from 999)")
+	c.flags.BoolVarP(&c.planOnly, "plan", "", false, "show the plan without applying it")
+	c.flags.SetOutput(os.Stdout)
+	c.flags.Usage = func() {
+		fmt.Fprint(c.flags.Output(), tools.Unindent(`
+			Usage: kwctl memory [options] move <range> <dest>
+			       kwctl memory [options] swap <range> <range>
+			       kwctl memory [options] sort <range>
+			       kwctl memory [options] compact <range>
+			       kwctl memory [options] insert <slot> [<end>]
+
+			Reorganise memory channels.
+
+			Subcommands:
+				move       Move the channels in range to consecutive channels starting at dest
+				swap       Exchange the channels in two ranges of the same size
+				sort       Sort the channels in range by frequency or name
+				compact    Move the channels in range up to remove empty channels
+				insert     Free slot by shifting it and following channels down by one,
+				           up to the first empty channel at or before end (default 999)
+
+			The changes are first computed as a plan, which is shown before it is
+			applied. The plan is ordered so that if it is interrupted, the content
+			of every channel is still present somewhere in memory.
+
+			Options:
+			`))
+		c.flags.PrintDefaults()
+	}
+	return nil
+}
+
+func (c *MemoryCommand) Run(r *radio.Radio, ctx config.Context, args []string) error {
+	if err := c.flags.Parse(args); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	if c.flags.NArg() < 1 {
+		return fmt.Errorf("missing subcommand")
+	}
+
+	subcommand, subargs := c.flags.Arg(0), c.flags.Args()[1:]
+
+	var (
+		slots    []int
+		layout   func(map[int]types.Channel) (memory.Layout, error)
+		channels = make(map[int]types.Channel)
+	)
+
+	switch subcommand {
+	case "move":
+		if len(subargs) != 2 {
+			return fmt.Errorf("usage: memory move <range> <dest>")
+		}
+		src, err := parseChannelRange(subargs[0])
+		if err != nil {
+			return err
+		}
+		dest, err := parseChannelNumber(subargs[1])
+		if err != nil {
+			return err
+		}
+		if dest+len(src)-1 > radio.MaxChannel {
+			return fmt.Errorf("destination range extends past channel %d", radio.MaxChannel)
+		}
+		slots = append(slots, src...)
+		for i := range src {
+			slots = append(slots, dest+i)
+		}
+		layout = func(channels map[int]types.Channel) (memory.Layout, error) {
+			return memory.Move(channels, src, dest)
+		}
+	case "swap":
+		if len(subargs) != 2 {
+			return fmt.Errorf("usage: memory swap <range> <range>")
+		}
+		a, err := parseChannelRange(subargs[0])
+		if err != nil {
+			return err
+		}
+		b, err := parseChannelRange(subargs[1])
+		if err != nil {
+			return err
+		}
+		slots = append(a, b...)
+		layout = func(map[int]types.Channel) (memory.Layout, error) {
+			return memory.Swap(a, b)
+		}
+	case "sort", "compact":
+		if len(subargs) != 1 {
+			return fmt.Errorf("usage: memory %s <range>", subcommand)
+		}
+		var err error
+		slots, err = parseChannelRange(subargs[0])
+		if err != nil {
+			return err
+		}
+		layout = func(channels map[int]types.Channel) (memory.Layout, error) {
+			if subcommand == "sort" {
+				return memory.Sort(channels, slots, c.sortBy)
+			}
+			return memory.Compact(channels, slots), nil
+		}
+	case "insert":
+		if len(subargs) < 1 || len(subargs) > 2 {
+			return fmt.Errorf("usage: memory insert <slot> [<end>]")
+		}
+		slot, err := parseChannelNumber(subargs[0])
+		if err != nil {
+			return err
+		}
+		end := radio.MaxChannel
+		if len(subargs) == 2 {
+			end, err = parseChannelNumber(subargs[1])
+			if err != nil {
+				return err
+			}
+		}
+		if end < slot {
+			return fmt.Errorf("end channel %03d is before slot %03d", end, slot)
+		}
+		// Only the channels up to the first empty slot are affected, so
+		// read them one at a time rather than reading the whole range.
+		for i := slot; i <= end; i++ {
+			channel, err := readChannel(r, ctx, channels, i)
+			if err != nil {
+				return err
+			}
+			slots = append(slots, i)
+			if channel.RxFreq == 0 {
+				break
+			}
+		}
+		layout = func(channels map[int]types.Channel) (memory.Layout, error) {
+			return memory.Insert(channels, slot, end)
+		}
+	default:
+		return fmt.Errorf("unknown subcommand: %s", subcommand)
+	}
+
+	if err := readChannels(r, ctx, channels, slots); err != nil {
+		return err
+	}
+
+	desired, err := layout(channels)
+	if err != nil {
+		return fmt.Errorf("failed to compute layout: %w", err)
+	}
+
+	plan, err := c.makePlan(r, ctx, channels, desired)
+	if err != nil {
+		return err
+	}
+
+	if len(plan) == 0 {
+		fmt.Println("Nothing to do.")
+		return nil
+	}
+
+	fmt.Printf("Plan:\n\n%s\n\n", plan)
+	if c.planOnly {
+		return nil
+	}
+
+	return applyPlan(r, ctx, plan)
+}
+
+// makePlan computes a plan for the given layout. If the plan needs a
+// scratch channel and none was specified, the first empty channel counting
+// down from the highest channel is used.
+func (c *MemoryCommand) makePlan(r *radio.Radio, ctx config.Context, channels map[int]types.Channel, layout memory.Layout) (memory.Plan, error) {
+	scratch := c.scratch
+	if scratch != memory.Empty {
+		if err := readScratch(r, channels, scratch); err != nil {
+			return nil, err
+		}
+	}
+
+	plan, err := memory.MakePlan(channels, layout, scratch)
+	if !errors.Is(err, memory.ErrNeedScratch) || scratch != memory.Empty {
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute plan: %w", err)
+		}
+		return plan, nil
+	}
+
+	ctx.Logger.Info("searching for scratch channel")
+	for slot := radio.MaxChannel; slot >= 0; slot-- {
+		if _, exists := layout[slot]; exists {
+			continue
+		}
+		if err := readScratch(r, channels, slot); err != nil {
+			return nil, err
+		}
+		if channels[slot].RxFreq == 0 {
+			ctx.Logger.Info("using scratch channel", "channel", slot)
+			plan, err := memory.MakePlan(channels, layout, slot)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compute plan: %w", err)
+			}
+			return plan, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no empty channel available for use as scratch space")
+}
+
+// readScratch reads a candidate scratch channel into channels.
+func readScratch(r *radio.Radio, channels map[int]types.Channel, slot int) error {
+	if slot < 0 || slot > radio.MaxChannel {
+		return fmt.Errorf("invalid scratch channel %d", slot)
+	}
+	channel, err := getChannelOrEmpty(r, slot)
+	if err != nil {
+		return err
+	}
+	channels[slot] = channel
+	return nil
+}
+
+// readChannels reads the content of every channel in slots that is not
+// already in channels. Empty channels are returned with RxFreq 0.
+func readChannels(r *radio.Radio, ctx config.Context, channels map[int]types.Channel, slots []int) error {
+	for _, slot := range slots {
+		if _, err := readChannel(r, ctx, channels, slot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readChannel returns the content of a channel, reading it into channels if
+// it has not been read already.
+func readChannel(r *radio.Radio, ctx config.Context, channels map[int]types.Channel, slot int) (types.Channel, error) {
+	if channel, exists := channels[slot]; exists {
+		return channel, nil
+	}
+	ctx.Logger.Info("getting information for channel", "channel", slot)
+	channel, err := getChannelOrEmpty(r, slot)
+	if err != nil {
+		return types.EmptyChannel, err
+	}
+	channels[slot] = channel
+	return channel, nil
+}
+
+func getChannelOrEmpty(r *radio.Radio, slot int) (types.Channel, error) {
+	channel, err := r.GetMemoryChannel(slot)
+	if err != nil {
+		if errors.Is(err, radio.ErrUnavailableCommand) {
+			return types.Channel{Number: slot}, nil
+		}
+		return types.EmptyChannel, fmt.Errorf("failed to read channel %03d: %w", slot, err)
+	}
+	return channel, nil
+}
+
+// applyPlan executes each step of a plan. If a step fails, the steps that
+// were not applied are reported.
+func applyPlan(r *radio.Radio, ctx config.Context, plan memory.Plan) error {
+	for i, step := range plan {
+		ctx.Logger.Info("applying step", "step", i+1, "action", step.String())
+
+		// SetMemoryChannel also writes empty names, so no stale name is
+		// left behind.
+		var err error
+		if step.Clear {
+			err = r.ClearMemoryChannel(step.Dest)
+		} else {
+			err = r.SetMemoryChannel(step.Channel)
+		}
+
+		if err != nil {
+			fmt.Printf("Step %d failed; the following steps were not applied:\n\n%s\n", i+1, plan[i:])
+			return fmt.Errorf("step %d (%s) failed: %w", i+1, step, err)
+		}
+	}
+
+	return nil
+}
+
+// parseChannelRange parses a range specification into a list of channel
+// numbers, in the order given.
+func parseChannelRange(spec string) ([]int, error) {
+	var slots []int
+	for channelNumber, err := range tools.RangeIterator(spec) {
+		if err != nil {
+			return nil, fmt.Errorf("invalid range: %w", err)
+		}
+		if channelNumber < 0 || channelNumber > radio.MaxChannel {
+			return nil, fmt.Errorf("invalid range (channels must be between 0 and %d)", radio.MaxChannel)
+		}
+		if slices.Contains(slots, channelNumber) {
+			return nil, fmt.Errorf("invalid range (channel %03d appears more than once)", channelNumber)
+		}
+		slots = append(slots, channelNumber)
+	}
+	return slots, nil
+}
+
+func parseChannelNumber(spec string) (int, error) {
+	channelNumber, err := strconv.Atoi(spec)
+	if err != nil || channelNumber < 0 || channelNumber > radio.MaxChannel {
+		return 0, fmt.Errorf("invalid channel number %q (channels must be between 0 and %d)", spec, radio.MaxChannel)
+	}
+	return channelNumber, nil
+}
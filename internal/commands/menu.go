@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/larsks/gobot/tools"
+	"github.com/larsks/kwctl/internal/config"
+	"github.com/larsks/kwctl/internal/formatters"
+	"github.com/larsks/kwctl/pkg/radio"
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+type (
+	MenuCommand struct {
+		flags *flag.FlagSet
+	}
+)
+
+func init() {
+	Register("menu", &MenuCommand{})
+}
+
+func (c *MenuCommand) NeedsRadio() bool {
+	return true
+}
+
+func (c *MenuCommand) MutatesState() bool {
+	return true
+}
+
+func (c *MenuCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
+//nolint:errcheck
+func (c *MenuCommand) Init() error {
+	c.flags = flag.NewFlagSet("menu", flag.ContinueOnError)
+	c.flags.SetOutput(os.Stdout)
+	c.flags.Usage = func() {
+		fmt.Fprint(c.flags.Output(), tools.Unindent(`
+			Usage: kwctl menu [get [<setting> [...]]]
+			       kwctl menu set <setting>=<value> [...]
+
+			Show or change radio menu settings. With no arguments, show all
+			settings. Use "kwctl --pretty menu" to see the values accepted by
+			each setting.
+
+			Only beep, brightness and auto-power-off are supported. The
+			position of the other menu items in the MU command (including
+			the time-out timer and key lock) has not been confirmed for the
+			TM-V71, so they are not shown. The power-on message cannot be
+			read or set, since there is no CAT command for it.
+		`))
+		c.flags.PrintDefaults()
+	}
+	return nil
+}
+
+func (c *MenuCommand) Run(r *radio.Radio, ctx config.Context, args []string) error {
+	if err := c.flags.Parse(args); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	subcommand := "get"
+	var subargs []string
+	if c.flags.NArg() > 0 {
+		subcommand, subargs = c.flags.Arg(0), c.flags.Args()[1:]
+	}
+
+	menu, err := r.GetMenu()
+	if err != nil {
+		return fmt.Errorf("failed to read menu: %w", err)
+	}
+
+	var keys []string
+
+	switch subcommand {
+	case "get":
+		keys = subargs
+		if len(keys) == 0 {
+			keys = types.MenuKeys()
+		}
+	case "set":
+		if len(subargs) == 0 {
+			return fmt.Errorf("missing settings")
+		}
+
+		for _, arg := range subargs {
+			key, value, found := strings.Cut(arg, "=")
+			if !found {
+				return fmt.Errorf("invalid setting %q (expected <setting>=<value>)", arg)
+			}
+			if err := menu.Set(key, value); err != nil {
+				return fmt.Errorf("invalid setting: %w", err)
+			}
+			keys = append(keys, key)
+		}
+
+		if err := r.SetMenu(menu); err != nil {
+			return fmt.Errorf("failed to set menu: %w", err)
+		}
+
+		menu, err = r.GetMenu()
+		if err != nil {
+			return fmt.Errorf("failed to read menu: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown subcommand: %s", subcommand)
+	}
+
+	var formatter *formatters.TableFormatter
+	if ctx.Config.Pretty {
+		formatter = formatters.NewTableFormatter([]string{"Setting", "Value", "Allowed"})
+	}
+
+	for _, key := range keys {
+		value, err := menu.Get(key)
+		if err != nil {
+			return err
+		}
+
+		if ctx.Config.Pretty {
+			allowed, _ := types.MenuAllowedValues(key)
+			formatter.Update([][]string{{key, value, allowed}})
+		} else {
+			fmt.Printf("%s=%s\n", key, value)
+		}
+	}
+
+	if ctx.Config.Pretty {
+		formatter.Render(nil)
+	}
+
+	return nil
+}
@@ -26,6 +26,14 @@ func (c *ModeCommand) NeedsRadio() bool {
 	return true
 }
 
+func (c *ModeCommand) MutatesState() bool {
+	return true
+}
+
+func (c *ModeCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
 func (c *ModeCommand) Init() error {
 	c.flags = flag.NewFlagSet("mode", flag.ContinueOnError)
 	c.flags.SetOutput(os.Stdout)
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/larsks/gobot/tools"
+	"github.com/larsks/kwctl/internal/config"
+	"github.com/larsks/kwctl/pkg/radio"
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+type (
+	PMCommand struct {
+		flags *flag.FlagSet
+	}
+)
+
+func init() {
+	Register("pm", &PMCommand{})
+}
+
+func (c *PMCommand) NeedsRadio() bool {
+	return true
+}
+
+func (c *PMCommand) MutatesState() bool {
+	return true
+}
+
+func (c *PMCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
+func (c *PMCommand) Init() error {
+	c.flags = flag.NewFlagSet("pm", flag.ContinueOnError)
+	c.flags.SetOutput(os.Stdout)
+	c.flags.Usage = func() {
+		//nolint:errcheck
+		fmt.Fprint(c.flags.Output(), tools.Unindent(`
+			Usage: kwctl pm [1-5|off]
+
+			Get or select the active programmable memory. Selecting a
+			programmable memory replaces the current radio configuration with
+			the one stored in that slot.
+		`))
+		c.flags.PrintDefaults()
+	}
+	return nil
+}
+
+func (c *PMCommand) Run(r *radio.Radio, ctx config.Context, args []string) error {
+	if err := c.flags.Parse(args); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	if c.flags.NArg() == 1 {
+		pm, err := types.ParsePM(c.flags.Arg(0))
+		if err != nil {
+			return fmt.Errorf("failed to parse programmable memory: %w", err)
+		}
+		if err := r.SetPM(pm); err != nil {
+			return fmt.Errorf("failed to set programmable memory: %w", err)
+		}
+	}
+
+	pm, err := r.GetPM()
+	if err != nil {
+		return fmt.Errorf("failed to get programmable memory: %w", err)
+	}
+
+	fmt.Printf("%s\n", pm)
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/larsks/gobot/tools"
+	"github.com/larsks/kwctl/internal/config"
+	"github.com/larsks/kwctl/pkg/radio"
+)
+
+type (
+	PowerCommand struct {
+		flags *flag.FlagSet
+	}
+)
+
+func init() {
+	Register("power", &PowerCommand{})
+}
+
+func (c *PowerCommand) NeedsRadio() bool {
+	return true
+}
+
+func (c *PowerCommand) MutatesState() bool {
+	return true
+}
+
+// SkipCheck allows power to be used when the radio is off and does not
+// answer the ID command.
+func (c *PowerCommand) SkipCheck() bool {
+	return true
+}
+
+func (c *PowerCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
+func (c *PowerCommand) Init() error {
+	c.flags = flag.NewFlagSet("power", flag.ContinueOnError)
+	c.flags.SetOutput(os.Stdout)
+	c.flags.Usage = func() {
+		//nolint:errcheck
+		fmt.Fprint(c.flags.Output(), tools.Unindent(`
+			Usage: kwctl power [on|off]
+
+			Get or set the radio power state.
+		`))
+		c.flags.PrintDefaults()
+	}
+	return nil
+}
+
+func (c *PowerCommand) Run(r *radio.Radio, ctx config.Context, args []string) error {
+	if err := c.flags.Parse(args); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	if c.flags.NArg() == 1 {
+		on, err := parseOnOff(c.flags.Arg(0))
+		if err != nil {
+			return fmt.Errorf("failed to parse power state: %w", err)
+		}
+		if err := r.SetPower(on); err != nil {
+			return fmt.Errorf("failed to set power state: %w", err)
+		}
+		// The radio may not answer further commands once it has been
+		// turned off, so report the requested state.
+		fmt.Printf("%s\n", formatOnOff(on))
+		return nil
+	}
+
+	on, err := r.GetPower()
+	if err != nil {
+		return fmt.Errorf("failed to get power state: %w", err)
+	}
+
+	fmt.Printf("%s\n", formatOnOff(on))
+
+	return nil
+}
+
+func parseOnOff(s string) (bool, error) {
+	switch s {
+	case "on":
+		return true, nil
+	case "off":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid value: %s (must be on or off)", s)
+	}
+}
+
+func formatOnOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
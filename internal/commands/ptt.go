@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/larsks/gobot/tools"
+	"github.com/larsks/kwctl/internal/config"
+	"github.com/larsks/kwctl/pkg/radio"
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+type (
+	PttCommand struct {
+		flags       *flag.FlagSet
+		duration    time.Duration
+		iAmLicensed bool
+	}
+)
+
+// maxTransmitDuration is the longest time for which ptt will key the
+// transmitter.
+const maxTransmitDuration = 3 * time.Minute
+
+func init() {
+	Register("ptt", &PttCommand{})
+}
+
+func (c *PttCommand) NeedsRadio() bool {
+	return true
+}
+
+func (c *PttCommand) MutatesState() bool {
+	return true
+}
+
+func (c *PttCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
+//nolint:errcheck
+func (c *PttCommand) Init() error {
+	c.flags = flag.NewFlagSet("ptt", flag.ContinueOnError)
+	c.flags.DurationVarP(&c.duration, "duration", "t", 5*time.Second, fmt.Sprintf("how long to transmit (at most %s)", maxTransmitDuration))
+	c.flags.BoolVarP(&c.iAmLicensed, "i-am-licensed", "", false, "confirm that you are licensed to transmit")
+	c.flags.SetOutput(os.Stdout)
+	c.flags.Usage = func() {
+		fmt.Fprint(c.flags.Output(), tools.Unindent(`
+			Usage: kwctl ptt [options]
+
+			Key the transmitter on the PTT band for a fixed time.
+
+			Transmitting requires either the --i-am-licensed option or
+			"allow-transmit: true" in the radio profile. The transmitter is only
+			keyed if the whole signal, allowing for the bandwidth of the mode
+			(16 kHz for FM, 11 kHz for NFM, 6 kHz for AM), is inside an amateur
+			band. It is unkeyed when the duration expires, when kwctl is
+			interrupted, or if an error occurs.
+
+			Options:
+		`))
+		c.flags.PrintDefaults()
+	}
+	return nil
+}
+
+func (c *PttCommand) Run(r *radio.Radio, ctx config.Context, args []string) (err error) {
+	if err := c.flags.Parse(args); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	if !c.iAmLicensed && !ctx.Config.AllowTransmit {
+		return fmt.Errorf("transmitting requires --i-am-licensed or allow-transmit in the radio profile")
+	}
+
+	if c.duration <= 0 || c.duration > maxTransmitDuration {
+		return fmt.Errorf("duration must be greater than 0 and at most %s", maxTransmitDuration)
+	}
+
+	band, err := r.GetPTTBand()
+	if err != nil {
+		return fmt.Errorf("failed to get ptt band: %w", err)
+	}
+
+	settings, err := r.GetTxSettings(strconv.Itoa(band))
+	if err != nil {
+		return fmt.Errorf("failed to get transmit frequency: %w", err)
+	}
+
+	txFreq, txMode := types.TxFrequency(settings), settings.GetMode()
+	amateurBand, ok := types.AmateurTxBand(txFreq, types.ModeBandwidth(txMode))
+	if !ok {
+		return fmt.Errorf("refusing to transmit %s on %s MHz (not inside an amateur band)", types.NewMode(&txMode), types.NewFrequencyMHz(&txFreq))
+	}
+
+	sigctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Unkey however we leave this function, including on error and panic.
+	// This is registered before keying in case the radio keys but the TX
+	// command does not complete.
+	defer func() {
+		ctx.Logger.Info("unkeying transmitter")
+		if rxErr := r.Receive(); rxErr != nil {
+			ctx.Logger.Error("failed to unkey transmitter", "error", rxErr)
+			if err == nil {
+				err = rxErr
+			}
+		}
+	}()
+
+	ctx.Logger.Info("keying transmitter", "band", band, "frequency", txFreq, "allocation", amateurBand.Name, "duration", c.duration)
+	if err := r.Transmit(); err != nil {
+		return err
+	}
+
+	watchdog := time.NewTimer(c.duration)
+	defer watchdog.Stop()
+
+	select {
+	case <-watchdog.C:
+	case <-sigctx.Done():
+		ctx.Logger.Warn("interrupted while transmitting")
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chzyer/readline"
+	flag "github.com/spf13/pflag"
+
+	"github.com/larsks/gobot/tools"
+	"github.com/larsks/kwctl/internal/config"
+	"github.com/larsks/kwctl/pkg/radio"
+)
+
+type (
+	RawCommand struct {
+		flags       *flag.FlagSet
+		interactive bool
+	}
+)
+
+func init() {
+	Register("raw", &RawCommand{})
+}
+
+func (c *RawCommand) NeedsRadio() bool {
+	return true
+}
+
+func (c *RawCommand) MutatesState() bool {
+	return true
+}
+
+func (c *RawCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
+//nolint:errcheck
+func (c *RawCommand) Init() error {
+	c.flags = flag.NewFlagSet("raw", flag.ContinueOnError)
+	c.flags.BoolVarP(&c.interactive, "interactive", "i", false, "interactive terminal mode")
+	c.flags.SetOutput(os.Stdout)
+	c.flags.Usage = func() {
+		fmt.Fprint(c.flags.Output(), tools.Unindent(`
+			Usage: kwctl raw <command> [<arg> [...]]
+			       kwctl raw -i
+
+			Send a CAT command to the radio and print the response line
+			exactly as the radio sent it. Multiple arguments are joined with
+			commas (so "raw BC 1 1" sends "BC 1,1").
+
+			In interactive mode, each line is sent to the radio as-is and both
+			the command and the response are shown with timestamps.
+
+			Options:
+		`))
+		c.flags.PrintDefaults()
+	}
+	return nil
+}
+
+func (c *RawCommand) Run(r *radio.Radio, ctx config.Context, args []string) error {
+	if err := c.flags.Parse(args); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	if c.interactive {
+		return c.runInteractive(r, ctx)
+	}
+
+	if c.flags.NArg() == 0 {
+		return fmt.Errorf("missing command")
+	}
+
+	res, err := r.SendRawCommand(c.flags.Arg(0), c.flags.Args()[1:]...)
+	if err != nil {
+		return fmt.Errorf("failed to send command: %w", err)
+	}
+
+	fmt.Printf("%s\n", res)
+	return nil
+}
+
+func (c *RawCommand) runInteractive(r *radio.Radio, ctx config.Context) error {
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          "raw> ",
+		InterruptPrompt: "^C",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize terminal: %w", err)
+	}
+	defer rl.Close() //nolint:errcheck
+
+	out := rl.Stdout()
+	for {
+		line, err := rl.Readline()
+		if errors.Is(err, readline.ErrInterrupt) {
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read command: %w", err)
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		// Everything after the command name is passed through unmodified.
+		var cmdArgs []string
+		cmd, rest, found := strings.Cut(line, " ")
+		if found {
+			cmdArgs = []string{strings.TrimSpace(rest)}
+		}
+
+		fmt.Fprintf(out, "%s > %s\n", timestamp(), line) //nolint:errcheck
+		res, err := r.SendRawCommand(cmd, cmdArgs...)
+		if err != nil {
+			ctx.Logger.Error("failed to send command", "command", cmd, "error", err)
+			continue
+		}
+		fmt.Fprintf(out, "%s < %s\n", timestamp(), res) //nolint:errcheck
+	}
+}
+
+func timestamp() string {
+	return time.Now().Format("15:04:05.000")
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/larsks/gobot/tools"
+	"github.com/larsks/kwctl/internal/config"
+	"github.com/larsks/kwctl/internal/formatters"
+	"github.com/larsks/kwctl/pkg/radio"
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+type (
+	RecallCommand struct {
+		flags        *flag.FlagSet
+		forceVfoMode bool
+	}
+)
+
+func init() {
+	Register("recall", &RecallCommand{})
+}
+
+func (c *RecallCommand) NeedsRadio() bool {
+	return true
+}
+
+func (c *RecallCommand) MutatesState() bool {
+	return true
+}
+
+func (c *RecallCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
+//nolint:errcheck
+func (c *RecallCommand) Init() error {
+	c.flags = flag.NewFlagSet("recall", flag.ContinueOnError)
+	c.flags.BoolVarP(&c.forceVfoMode, "force", "f", false, "change to vfo mode before tuning")
+	c.flags.SetOutput(os.Stdout)
+	c.flags.Usage = func() {
+		fmt.Fprint(c.flags.Output(), tools.Unindent(`
+			Usage: kwctl recall [options] <channel>
+
+			Load the settings of a memory channel into the selected VFO.
+
+			A channel with an odd split is converted into a shift and offset,
+			which fails if the offset is more than 29.95 MHz or is not a
+			multiple of 50 kHz.
+
+			Arguments:
+				channel    Channel number (0-999) or name
+
+			Options:
+		`))
+		c.flags.PrintDefaults()
+	}
+	return nil
+}
+
+func (c *RecallCommand) Run(r *radio.Radio, ctx config.Context, args []string) error {
+	if err := c.flags.Parse(args); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	if c.flags.NArg() != 1 {
+		return fmt.Errorf("recall requires a channel")
+	}
+
+	channelNumber, err := r.ResolveMemoryChannel(c.flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid channel: %w", err)
+	}
+
+	channel, err := r.GetMemoryChannel(channelNumber)
+	if err != nil {
+		return fmt.Errorf("failed to read channel %03d: %w", channelNumber, err)
+	}
+
+	vfo, err := r.GetVFO(ctx.Config.Vfo)
+	if err != nil {
+		return fmt.Errorf("failed to read vfo %s: %w", ctx.Config.Vfo, err)
+	}
+
+	vfo, err = channel.ToVFO(vfo.VFO)
+	if err != nil {
+		return fmt.Errorf("cannot recall channel: %w", err)
+	}
+
+	if c.forceVfoMode {
+		if err := r.SetVFOMode(ctx.Config.Vfo, types.VFO_MODE_VFO); err != nil {
+			return fmt.Errorf("failed to change to vfo mode: %w", err)
+		}
+	}
+
+	ctx.Logger.Info("recalling channel", "channel", channelNumber, "vfo", ctx.Config.Vfo)
+	if err := r.SetVFO(ctx.Config.Vfo, vfo); err != nil {
+		return fmt.Errorf("failed to tune vfo %s: %w", ctx.Config.Vfo, err)
+	}
+
+	vfo, err = r.GetVFO(ctx.Config.Vfo)
+	if err != nil {
+		return fmt.Errorf("failed to read vfo %s: %w", ctx.Config.Vfo, err)
+	}
+
+	if ctx.Config.Pretty {
+		formatter := formatters.NewTableFormatter(formatters.HeadersFromStruct(types.DisplayVFO{}))
+		formatter.Update([][]string{vfo.Values()})
+		formatter.Render(nil)
+	} else {
+		fmt.Println(strings.Join(vfo.Values(), ","))
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/larsks/gobot/tools"
+	"github.com/larsks/kwctl/internal/config"
+	"github.com/larsks/kwctl/pkg/radio"
+)
+
+type (
+	RunCommand struct {
+		flags     *flag.FlagSet
+		keepGoing bool
+	}
+
+	// scriptLine is a single command from a script along with the result
+	// of running it.
+	scriptLine struct {
+		number int
+		text   string
+		words  []string
+		status string
+		err    error
+	}
+)
+
+const (
+	scriptStatusOK      = "ok"
+	scriptStatusFailed  = "failed"
+	scriptStatusSkipped = "skipped"
+)
+
+func init() {
+	Register("run", &RunCommand{})
+}
+
+func (c *RunCommand) NeedsRadio() bool {
+	return true
+}
+
+func (c *RunCommand) MutatesState() bool {
+	return true
+}
+
+func (c *RunCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
+//nolint:errcheck
+func (c *RunCommand) Init() error {
+	c.flags = flag.NewFlagSet("run", flag.ContinueOnError)
+	c.flags.BoolVarP(&c.keepGoing, "keep-going", "k", false, "continue after a command fails")
+	c.flags.SetOutput(os.Stdout)
+	c.flags.Usage = func() {
+		fmt.Fprint(c.flags.Output(), tools.Unindent(`
+			Usage: kwctl run [options] <script>|-
+
+			Run the kwctl commands in a script against a single open radio
+			connection. Use "-" to read commands from stdin.
+
+			Each line is a kwctl command without the leading "kwctl" (e.g.
+			"edit 90 --rxfreq 146.82"). Blank lines and lines starting with
+			"#" are ignored. By default, execution stops at the first command
+			that fails.
+
+			Options:
+		`))
+		c.flags.PrintDefaults()
+	}
+	return nil
+}
+
+func (c *RunCommand) Run(r *radio.Radio, ctx config.Context, args []string) error {
+	if err := c.flags.Parse(args); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	if c.flags.NArg() != 1 {
+		return fmt.Errorf("missing script name")
+	}
+
+	var in io.Reader
+	if c.flags.Arg(0) == "-" {
+		in = os.Stdin
+	} else {
+		f, err := os.Open(c.flags.Arg(0))
+		if err != nil {
+			return fmt.Errorf("failed to open script: %w", err)
+		}
+		defer f.Close() //nolint:errcheck
+		in = f
+	}
+
+	lines, err := readScript(in)
+	if err != nil {
+		return fmt.Errorf("failed to read script: %w", err)
+	}
+
+	failed := 0
+	for i := range lines {
+		line := &lines[i]
+
+		if failed > 0 && !c.keepGoing {
+			line.status = scriptStatusSkipped
+			continue
+		}
+
+		ctx.Logger.Info("running command", "line", line.number, "command", line.text)
+		switch Lookup(line.words[0]).(type) {
+		case *ShellCommand, *RunCommand:
+			line.err = fmt.Errorf("%s cannot be used in a script", line.words[0])
+		default:
+			line.err = Execute(r, ctx, line.words)
+		}
+
+		if line.err != nil && !errors.Is(line.err, flag.ErrHelp) {
+			line.status = scriptStatusFailed
+			failed++
+		} else {
+			line.status = scriptStatusOK
+		}
+	}
+
+	printScriptSummary(os.Stdout, lines)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d commands failed", failed, len(lines))
+	}
+
+	return nil
+}
+
+// readScript reads commands from a script, discarding blank lines and
+// comments.
+func readScript(in io.Reader) ([]scriptLine, error) {
+	var lines []scriptLine
+
+	scanner := bufio.NewScanner(in)
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimSpace(scanner.Text())
+
+		words, err := SplitArgs(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number, err)
+		}
+		if len(words) == 0 {
+			continue
+		}
+
+		lines = append(lines, scriptLine{number: number, text: text, words: words})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+//nolint:errcheck
+func printScriptSummary(out io.Writer, lines []scriptLine) {
+	fmt.Fprintf(out, "\nSummary:\n\n")
+	for _, line := range lines {
+		fmt.Fprintf(out, "  %4d %-8s %s", line.number, line.status, line.text)
+		if line.status == scriptStatusFailed {
+			fmt.Fprintf(out, ": %v", line.err)
+		}
+		fmt.Fprintf(out, "\n")
+	}
+}
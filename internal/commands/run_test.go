@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadScript(t *testing.T) {
+	script := strings.Join([]string{
+		"# set up band A",
+		"bands dual",
+		"",
+		"  vfo 0  ",
+		`edit 90 --rxfreq 146.82 --name "BAKBAY" # repeater`,
+	}, "\n")
+
+	lines, err := readScript(strings.NewReader(script))
+	if err != nil {
+		t.Fatalf("readScript() failed: %v", err)
+	}
+
+	expected := []scriptLine{
+		{number: 2, text: "bands dual", words: []string{"bands", "dual"}},
+		{number: 4, text: "vfo 0", words: []string{"vfo", "0"}},
+		{
+			number: 5,
+			text:   `edit 90 --rxfreq 146.82 --name "BAKBAY" # repeater`,
+			words:  []string{"edit", "90", "--rxfreq", "146.82", "--name", "BAKBAY"},
+		},
+	}
+
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("readScript() = %+v, expected %+v", lines, expected)
+	}
+}
+
+func TestReadScriptInvalidLine(t *testing.T) {
+	_, err := readScript(strings.NewReader("bands dual\nedit 90 --name \"BAK\n"))
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("error does not identify line: %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/chzyer/readline"
+	flag "github.com/spf13/pflag"
+
+	"github.com/larsks/gobot/tools"
+	"github.com/larsks/kwctl/internal/config"
+	"github.com/larsks/kwctl/pkg/radio"
+)
+
+type (
+	ShellCommand struct {
+		flags       *flag.FlagSet
+		historyFile string
+	}
+
+	// shellCompleter completes command names for the first word on the line
+	// and flag names for subsequent words.
+	shellCompleter struct{}
+)
+
+// Words handled by the shell itself rather than by a registered command.
+var shellBuiltins = []string{"exit", "help", "quit"}
+
+func init() {
+	Register("shell", &ShellCommand{})
+}
+
+func (c *ShellCommand) NeedsRadio() bool {
+	return true
+}
+
+func (c *ShellCommand) MutatesState() bool {
+	return true
+}
+
+func (c *ShellCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
+//nolint:errcheck
+func (c *ShellCommand) Init() error {
+	c.flags = flag.NewFlagSet("shell", flag.ContinueOnError)
+	c.flags.StringVarP(&c.historyFile, "history", "", tools.GetenvWithDefault("KWCTL_HISTORY", defaultHistoryFile()), "history file (empty to disable)")
+	c.flags.SetOutput(os.Stdout)
+	c.flags.Usage = func() {
+		fmt.Fprint(c.flags.Output(), tools.Unindent(`
+			Usage: kwctl shell [options]
+
+			Run commands interactively against a single open radio connection.
+
+			Each line is a kwctl command without the leading "kwctl" (e.g.
+			"list 1-10"). Use "help" to list commands and "exit", "quit" or
+			Ctrl-D to leave the shell.
+
+			Options:
+		`))
+		c.flags.PrintDefaults()
+	}
+	return nil
+}
+
+func (c *ShellCommand) Run(r *radio.Radio, ctx config.Context, args []string) error {
+	if err := c.flags.Parse(args); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          "kwctl> ",
+		HistoryFile:     c.historyFile,
+		AutoComplete:    shellCompleter{},
+		InterruptPrompt: "^C",
+		EOFPrompt:       "exit",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize shell: %w", err)
+	}
+	defer rl.Close() //nolint:errcheck
+
+	for {
+		line, err := rl.Readline()
+		if errors.Is(err, readline.ErrInterrupt) {
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read command: %w", err)
+		}
+
+		words, err := SplitArgs(line)
+		if err != nil {
+			ctx.Logger.Error("invalid command line", "error", err)
+			continue
+		}
+		if len(words) == 0 {
+			continue
+		}
+
+		switch words[0] {
+		case "exit", "quit":
+			return nil
+		case "help":
+			Help(rl.Stdout())
+			continue
+		}
+
+		if Lookup(words[0]) == Command(c) {
+			ctx.Logger.Error("already running a shell")
+			continue
+		}
+
+		if err := Execute(r, ctx, words); err != nil && !errors.Is(err, flag.ErrHelp) {
+			ctx.Logger.Error("command failed", "command", words[0], "error", err)
+		}
+	}
+}
+
+func defaultHistoryFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".kwctl_history")
+}
+
+func (shellCompleter) Do(line []rune, pos int) ([][]rune, int) {
+	text := string(line[:pos])
+	words := strings.Fields(text)
+
+	// The word being completed is the last one, unless the cursor follows
+	// whitespace, in which case we are starting a new word.
+	partial := ""
+	if len(words) > 0 && !unicode.IsSpace(line[pos-1]) {
+		partial = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	var candidates []string
+	if len(words) == 0 {
+		candidates = append(List(), shellBuiltins...)
+	} else if cmd, ok := Lookup(words[0]).(FlagCommand); ok && strings.HasPrefix(partial, "-") {
+		cmd.Flags().VisitAll(func(f *flag.Flag) {
+			candidates = append(candidates, "--"+f.Name)
+		})
+	}
+
+	var matches [][]rune
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, partial) {
+			matches = append(matches, []rune(candidate[len(partial):]+" "))
+		}
+	}
+
+	return matches, len([]rune(partial))
+}
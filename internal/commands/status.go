@@ -26,6 +26,14 @@ func (c *StatusCommand) NeedsRadio() bool {
 	return true
 }
 
+func (c *StatusCommand) MutatesState() bool {
+	return false
+}
+
+func (c *StatusCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
 //nolint:errcheck
 func (c *StatusCommand) Init() error {
 	c.flags = flag.NewFlagSet("status", flag.ContinueOnError)
@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/larsks/gobot/tools"
+	"github.com/larsks/kwctl/internal/config"
+	"github.com/larsks/kwctl/pkg/radio"
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+type (
+	StepCommand struct {
+		flags        *flag.FlagSet
+		step         int
+		forceVfoMode bool
+	}
+)
+
+// negativeCount matches a negative step count, which pflag would
+// otherwise try to parse as a short option.
+var negativeCount = regexp.MustCompile(`^-[0-9]+$`)
+
+func init() {
+	Register("step", &StepCommand{})
+}
+
+func (c *StepCommand) NeedsRadio() bool {
+	return true
+}
+
+func (c *StepCommand) MutatesState() bool {
+	return true
+}
+
+func (c *StepCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
+//nolint:errcheck
+func (c *StepCommand) Init() error {
+	c.flags = flag.NewFlagSet("step", flag.ContinueOnError)
+	c.flags.BoolVarP(&c.forceVfoMode, "force", "f", false, "change to vfo mode before stepping")
+	c.flags.VarP(types.NewStepSize(&c.step), "step", "", "step size in khz (e.g., 5) instead of the vfo's step size")
+	// There is no useful default to show, since it comes from the vfo
+	c.flags.Lookup("step").DefValue = ""
+	c.flags.SetOutput(os.Stdout)
+	c.flags.Usage = func() {
+		fmt.Fprint(c.flags.Output(), tools.Unindent(`
+			Usage: kwctl step [options] [+|-]N
+
+			Move the selected VFO up or down by N steps (default 1) and print
+			the new frequency. The frequency is first aligned to the step
+			size. Giving --step also sets the step size of the VFO. The VFO
+			must be in vfo mode unless --force is given.
+
+			Stepping stops at the edge of the band containing the frequency:
+			the amateur band (2m, 1.25m, 70cm or 23cm) if there is one,
+			otherwise air (118-137 MHz), vhf (30-300 MHz) or uhf (300 MHz
+			and up). It does not continue into receive-only spectrum beyond
+			an amateur band edge; use "kwctl tune" to go there.
+
+			Options:
+		`))
+		c.flags.PrintDefaults()
+	}
+	return nil
+}
+
+func (c *StepCommand) Run(r *radio.Radio, ctx config.Context, args []string) error {
+	var counts, flagArgs []string
+	for _, arg := range args {
+		if negativeCount.MatchString(arg) {
+			counts = append(counts, arg)
+		} else {
+			flagArgs = append(flagArgs, arg)
+		}
+	}
+
+	if err := c.flags.Parse(flagArgs); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+	counts = append(counts, c.flags.Args()...)
+
+	n := 1
+	switch len(counts) {
+	case 0:
+	case 1:
+		var err error
+		n, err = strconv.Atoi(counts[0])
+		if err != nil {
+			return fmt.Errorf("invalid step count: %s", counts[0])
+		}
+	default:
+		return fmt.Errorf("step takes at most one argument")
+	}
+
+	mode, err := r.GetVFOMode(ctx.Config.Vfo)
+	if err != nil {
+		return err
+	}
+	if mode != types.VFO_MODE_VFO {
+		if !c.forceVfoMode {
+			return fmt.Errorf("vfo %s is in %s mode (use --force to change to vfo mode)", ctx.Config.Vfo, mode)
+		}
+		if err := r.SetVFOMode(ctx.Config.Vfo, types.VFO_MODE_VFO); err != nil {
+			return fmt.Errorf("failed to change to vfo mode: %w", err)
+		}
+	}
+
+	vfo, err := r.GetVFO(ctx.Config.Vfo)
+	if err != nil {
+		return fmt.Errorf("failed to read vfo %s: %w", ctx.Config.Vfo, err)
+	}
+
+	if c.flags.Changed("step") {
+		vfo.RxStep = c.step
+	}
+
+	freq := types.Frequency(vfo.RxFreq).AddSteps(n, vfo.RxStep)
+	if band, ok := types.BandOf(vfo.RxFreq); ok {
+		if clamped := band.ClampStep(freq, vfo.RxStep); clamped != freq {
+			ctx.Logger.Warn("stopped at band edge", "band", band.Name, "frequency", clamped)
+			freq = clamped
+		}
+	}
+	if freq < 0 {
+		return fmt.Errorf("cannot step below 0 MHz")
+	}
+
+	vfo.RxFreq = int(freq)
+	if err := r.SetVFO(ctx.Config.Vfo, vfo); err != nil {
+		return fmt.Errorf("failed to tune vfo %s: %w", ctx.Config.Vfo, err)
+	}
+
+	vfo, err = r.GetVFO(ctx.Config.Vfo)
+	if err != nil {
+		return fmt.Errorf("failed to read vfo %s: %w", ctx.Config.Vfo, err)
+	}
+
+	fmt.Printf("%s\n", types.Frequency(vfo.RxFreq))
+	return nil
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/larsks/gobot/tools"
+	"github.com/larsks/kwctl/internal/config"
+	"github.com/larsks/kwctl/internal/formatters"
+	"github.com/larsks/kwctl/pkg/radio"
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+type (
+	StoreCommand struct {
+		flags       *flag.FlagSet
+		channelName string
+		force       bool
+	}
+)
+
+func init() {
+	Register("store", &StoreCommand{})
+}
+
+func (c *StoreCommand) NeedsRadio() bool {
+	return true
+}
+
+func (c *StoreCommand) MutatesState() bool {
+	return true
+}
+
+func (c *StoreCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
+//nolint:errcheck
+func (c *StoreCommand) Init() error {
+	c.flags = flag.NewFlagSet("store", flag.ContinueOnError)
+	c.flags.StringVarP(&c.channelName, "name", "n", "", "set channel name")
+	c.flags.BoolVarP(&c.force, "force", "f", false, "overwrite a channel that is already in use")
+	c.flags.SetOutput(os.Stdout)
+	c.flags.Usage = func() {
+		fmt.Fprint(c.flags.Output(), tools.Unindent(`
+			Usage: kwctl store [options] <channel>
+
+			Store the settings of the selected VFO in a memory channel.
+			A channel given by name keeps its name unless --name is used.
+
+			Arguments:
+				channel    Channel number (0-999) or name
+
+			Options:
+		`))
+		c.flags.PrintDefaults()
+	}
+	return nil
+}
+
+func (c *StoreCommand) Run(r *radio.Radio, ctx config.Context, args []string) error {
+	if err := c.flags.Parse(args); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	if c.flags.NArg() != 1 {
+		return fmt.Errorf("store requires a channel")
+	}
+
+	channelNumber, err := r.ResolveMemoryChannel(c.flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid channel: %w", err)
+	}
+
+	existing, err := r.GetMemoryChannel(channelNumber)
+	inUse := err == nil
+	if err != nil && !errors.Is(err, radio.ErrUnavailableCommand) {
+		return fmt.Errorf("failed to read channel %03d: %w", channelNumber, err)
+	}
+	if inUse && !c.force {
+		return fmt.Errorf("channel %03d is in use (use --force to overwrite it)", channelNumber)
+	}
+
+	vfo, err := r.GetVFO(ctx.Config.Vfo)
+	if err != nil {
+		return fmt.Errorf("failed to read vfo %s: %w", ctx.Config.Vfo, err)
+	}
+
+	// A channel addressed by name keeps that name unless --name is given
+	name := c.channelName
+	if _, err := strconv.Atoi(c.flags.Arg(0)); err != nil && !c.flags.Changed("name") {
+		name = existing.Name
+	}
+	channel := vfo.ToChannel(channelNumber, name)
+
+	ctx.Logger.Info("storing vfo", "vfo", ctx.Config.Vfo, "channel", channelNumber)
+	if err := r.SetMemoryChannel(channel); err != nil {
+		return fmt.Errorf("failed to set channel %d: %w", channelNumber, err)
+	}
+
+	channel, err = r.GetMemoryChannel(channelNumber)
+	if err != nil {
+		return fmt.Errorf("failed to read channel %03d: %w", channelNumber, err)
+	}
+
+	if ctx.Config.Pretty {
+		formatter := formatters.NewTableFormatter(formatters.HeadersFromStruct(types.DisplayChannel{}))
+		formatter.Update([][]string{channel.Values()})
+		formatter.Render(nil)
+	} else {
+		fmt.Printf("%s\n", channel)
+	}
+
+	return nil
+}
@@ -0,0 +1,347 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chzyer/readline"
+	flag "github.com/spf13/pflag"
+
+	"github.com/larsks/gobot/tools"
+	"github.com/larsks/kwctl/internal/config"
+	"github.com/larsks/kwctl/pkg/radio"
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+type (
+	TuiCommand struct {
+		flags    *flag.FlagSet
+		interval time.Duration
+	}
+
+	// frontPanel holds the state of the terminal user interface.
+	frontPanel struct {
+		r       *radio.Radio
+		out     io.Writer
+		band    int
+		status  types.Status
+		message string
+	}
+)
+
+const (
+	// ANSI escape sequences used to drive the terminal.
+	ansiEnterAltScreen = "\x1b[?1049h\x1b[?25l"
+	ansiExitAltScreen  = "\x1b[?25h\x1b[?1049l"
+	ansiClearScreen    = "\x1b[H\x1b[2J"
+	ansiBold           = "\x1b[1m"
+	ansiReverse        = "\x1b[7m"
+	ansiReset          = "\x1b[0m"
+
+	panelColumnWidth = 38
+)
+
+var (
+	bandNames = []string{"A", "B"}
+
+	// Escape sequences sent by the terminal for special keys.
+	keySequences = map[string]string{
+		"\x1b[A": "up",
+		"\x1b[B": "down",
+		"\x1b[C": "right",
+		"\x1b[D": "left",
+		"\t":     "tab",
+		"\x03":   "ctrl-c",
+	}
+)
+
+func init() {
+	Register("tui", &TuiCommand{})
+}
+
+func (c *TuiCommand) NeedsRadio() bool {
+	return true
+}
+
+func (c *TuiCommand) MutatesState() bool {
+	return true
+}
+
+func (c *TuiCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
+//nolint:errcheck
+func (c *TuiCommand) Init() error {
+	c.flags = flag.NewFlagSet("tui", flag.ContinueOnError)
+	c.flags.DurationVarP(&c.interval, "interval", "i", 2*time.Second, "status refresh interval")
+	c.flags.SetOutput(os.Stdout)
+	c.flags.Usage = func() {
+		fmt.Fprint(c.flags.Output(), tools.Unindent(`
+			Usage: kwctl tui [options]
+
+			Display a live view of both bands, similar to the radio's front panel.
+
+			Keys:
+				tab, left, right    select band
+				up, down, +, -      next/previous channel (memory mode) or step (vfo mode)
+				m                   toggle vfo/memory mode
+				p                   cycle tx power
+				c                   make the selected band the control band
+				t                   make the selected band the ptt band
+				r                   refresh now
+				q                   quit
+
+			Options:
+		`))
+		c.flags.PrintDefaults()
+	}
+	return nil
+}
+
+func (c *TuiCommand) Run(r *radio.Radio, ctx config.Context, args []string) error {
+	if err := c.flags.Parse(args); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !readline.IsTerminal(fd) {
+		return fmt.Errorf("tui requires a terminal")
+	}
+
+	state, err := readline.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to configure terminal: %w", err)
+	}
+	defer readline.Restore(fd, state) //nolint:errcheck
+
+	fmt.Print(ansiEnterAltScreen)
+	defer fmt.Print(ansiExitAltScreen)
+
+	stdin := readline.NewCancelableStdin(os.Stdin)
+	defer stdin.Close() //nolint:errcheck
+
+	keys := make(chan string)
+	done := make(chan struct{})
+	defer close(done)
+	go readKeys(stdin, keys, done)
+
+	panel := &frontPanel{r: r, out: os.Stdout}
+	panel.refresh()
+	panel.band = panel.status.CtlVfo
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		panel.render()
+
+		select {
+		case <-ticker.C:
+			panel.refresh()
+		case key, ok := <-keys:
+			if !ok || key == "q" || key == "ctrl-c" {
+				return nil
+			}
+			panel.message = ""
+			if err := panel.handleKey(key); err != nil {
+				ctx.Logger.Info("action failed", "key", key, "error", err)
+				panel.message = err.Error()
+			}
+			panel.refresh()
+		}
+	}
+}
+
+// readKeys translates terminal input into key names until the input is
+// closed or done is closed.
+func readKeys(in io.Reader, keys chan<- string, done <-chan struct{}) {
+	buf := make([]byte, 16)
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			close(keys)
+			return
+		}
+
+		input := string(buf[:n])
+		for input != "" {
+			name, seq := matchKey(input)
+			input = input[len(seq):]
+			select {
+			case keys <- name:
+			case <-done:
+				return
+			}
+		}
+	}
+}
+
+// matchKey returns the name of the key at the start of input along with
+// the input sequence that produced it.
+func matchKey(input string) (string, string) {
+	for seq, name := range keySequences {
+		if strings.HasPrefix(input, seq) {
+			return name, seq
+		}
+	}
+	return input[:1], input[:1]
+}
+
+func (p *frontPanel) vfo() string {
+	return fmt.Sprintf("%d", p.band)
+}
+
+func (p *frontPanel) refresh() {
+	status, err := p.r.GetStatus()
+	if err != nil {
+		p.message = err.Error()
+		return
+	}
+	p.status = status
+}
+
+func (p *frontPanel) handleKey(key string) error {
+	current := p.status.Vfos[p.band]
+
+	switch key {
+	case "tab", "left", "right":
+		p.band = 1 - p.band
+	case "up", "+", "down", "-":
+		direction := 1
+		if key == "down" || key == "-" {
+			direction = -1
+		}
+		if current.Mode == types.VFO_MODE_MEMORY.String() {
+			return p.stepChannel(current.ChannelNumber, direction)
+		}
+		return p.stepVFO(direction)
+	case "m":
+		mode := types.VFO_MODE_MEMORY
+		if current.Mode == types.VFO_MODE_MEMORY.String() {
+			mode = types.VFO_MODE_VFO
+		}
+		return p.r.SetVFOMode(p.vfo(), mode)
+	case "p":
+		power, err := types.ParseTxPower(current.TxPower)
+		if err != nil {
+			return err
+		}
+		return p.r.SetTxPower(p.vfo(), (power+1)%3)
+	case "c":
+		return p.r.SetControlBand(p.band)
+	case "t":
+		return p.r.SetPTTBand(p.band)
+	}
+
+	return nil
+}
+
+// stepChannel selects the next memory channel in the given direction,
+// skipping empty channels.
+func (p *frontPanel) stepChannel(channelNumber, direction int) error {
+	for i := 1; i < 1000; i++ {
+		next := (channelNumber + direction*i + 1000) % 1000
+		err := p.r.SetCurrentChannel(p.vfo(), next)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, radio.ErrUnavailableCommand) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("no memory channels available")
+}
+
+// stepVFO emulates the microphone up/down keys, which act on the control
+// band.
+func (p *frontPanel) stepVFO(direction int) error {
+	if p.status.CtlVfo != p.band {
+		if err := p.r.SetControlBand(p.band); err != nil {
+			return err
+		}
+	}
+
+	if direction > 0 {
+		return p.r.MicUp()
+	}
+	return p.r.MicDown()
+}
+
+//nolint:errcheck
+func (p *frontPanel) render() {
+	var b strings.Builder
+
+	b.WriteString(ansiClearScreen)
+	fmt.Fprintf(&b, "%skwctl%s  %s band  pm %s  %s\r\n\r\n", ansiBold, ansiReset, p.status.BandMode, p.status.PM, time.Now().Format("15:04:05"))
+
+	columns := [][]string{p.bandColumn(0), p.bandColumn(1)}
+	for i := range columns[0] {
+		for band, column := range columns {
+			line := column[i]
+			if i == 0 && band == p.band {
+				line = ansiReverse + line + ansiReset + strings.Repeat(" ", max(0, panelColumnWidth-len(column[i])))
+			} else {
+				line = fmt.Sprintf("%-*s", panelColumnWidth, line)
+			}
+			b.WriteString(line)
+		}
+		b.WriteString("\r\n")
+	}
+
+	b.WriteString("\r\n")
+	b.WriteString("tab: band  up/down: channel/step  m: vfo/mem  p: power  c: ctl  t: ptt  r: refresh  q: quit\r\n")
+	if p.message != "" {
+		fmt.Fprintf(&b, "\r\n%s\r\n", p.message)
+	}
+
+	fmt.Fprint(p.out, b.String())
+}
+
+// bandColumn produces the lines of text describing a single band.
+func (p *frontPanel) bandColumn(band int) []string {
+	v := p.status.Vfos[band]
+
+	title := fmt.Sprintf(" %s ", bandNames[band])
+	if p.status.CtlVfo == band {
+		title += " CTL"
+	}
+	if p.status.PttVfo == band {
+		title += " PTT"
+	}
+
+	memory := v.Mode
+	if v.Mode == types.VFO_MODE_MEMORY.String() {
+		memory = fmt.Sprintf("%s %03d %s", v.Mode, v.ChannelNumber, v.ChannelName)
+	}
+
+	return []string{
+		title,
+		"",
+		fmt.Sprintf("  %s MHz", v.Vfo.RxFreq),
+		fmt.Sprintf("  %s", memory),
+		fmt.Sprintf("  %s  shift %s %s", v.Vfo.Mode, v.Vfo.Shift, v.Vfo.Offset),
+		fmt.Sprintf("  %s", toneSummary(v.Vfo)),
+		fmt.Sprintf("  power %s", v.TxPower),
+	}
+}
+
+func toneSummary(v types.DisplayVFO) string {
+	switch v.ToneMode {
+	case "dcs":
+		return "dcs " + v.DCSCode
+	case "tsql":
+		return "tsql " + v.CTCSSFreq
+	case "tone":
+		return "tone " + v.ToneFreq
+	case "none":
+		return "no tone"
+	default:
+		return "tone " + v.ToneMode
+	}
+}
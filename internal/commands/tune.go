@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	flag "github.com/spf13/pflag"
 
@@ -17,6 +18,7 @@ type (
 	TuneCommand struct {
 		flags        *flag.FlagSet
 		forceVfoMode bool
+		txFreq       int
 		radioFlags   types.RadioFlagValues
 	}
 )
@@ -29,6 +31,14 @@ func (c *TuneCommand) NeedsRadio() bool {
 	return true
 }
 
+func (c *TuneCommand) MutatesState() bool {
+	return true
+}
+
+func (c *TuneCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
 //nolint:errcheck
 func (c *TuneCommand) Init() error {
 	c.flags = flag.NewFlagSet("id", flag.ContinueOnError)
@@ -36,6 +46,7 @@ func (c *TuneCommand) Init() error {
 
 	// Add common radio setting flags
 	types.AddRadioSettingFlags(c.flags, &c.radioFlags)
+	c.flags.VarP(types.NewFrequencyMHz(&c.txFreq), "txfreq", "", "transmit frequency in MHz for odd splits (sets shift and offset)")
 
 	c.flags.SetOutput(os.Stdout)
 	c.flags.Usage = func() {
@@ -44,6 +55,10 @@ func (c *TuneCommand) Init() error {
 
 			Tune the selected VFO.
 
+			The VFO has no separate transmit frequency, so --txfreq is
+			converted into a shift and offset. The offset must be at most
+			29.95 MHz, in steps of 50 kHz.
+
 			Options:
 		`))
 		c.flags.PrintDefaults()
@@ -63,7 +78,18 @@ func (c *TuneCommand) Run(r *radio.Radio, ctx config.Context, args []string) err
 	oldVfo := vfo
 
 	// Apply common radio settings
-	types.ApplyRadioSettingFlags(c.flags, &c.radioFlags, &vfo)
+	if err := types.ApplyRadioSettingFlags(c.flags, &c.radioFlags, &vfo); err != nil {
+		return fmt.Errorf("invalid settings: %w", err)
+	}
+
+	if c.flags.Changed("txfreq") {
+		if c.flags.Changed("shift") || c.flags.Changed("offset") || c.flags.Changed("reverse") {
+			return fmt.Errorf("--txfreq cannot be used with --shift, --offset or --reverse")
+		}
+		if err := vfo.SetSplit(c.txFreq); err != nil {
+			return fmt.Errorf("invalid settings: %w", err)
+		}
+	}
 
 	if vfo != oldVfo {
 		if c.forceVfoMode {
@@ -83,11 +109,11 @@ func (c *TuneCommand) Run(r *radio.Radio, ctx config.Context, args []string) err
 	}
 
 	if ctx.Config.Pretty {
-		formatter := formatters.NewTableFormatter(formatters.HeadersFromStruct(types.VFO{}))
+		formatter := formatters.NewTableFormatter(formatters.HeadersFromStruct(types.DisplayVFO{}))
 		formatter.Update([][]string{vfo.Values()})
 		formatter.Render(nil)
 	} else {
-		fmt.Printf("%s\n", vfo)
+		fmt.Println(strings.Join(vfo.Values(), ","))
 	}
 
 	return nil
@@ -26,6 +26,14 @@ func (c *TxPowerCommand) NeedsRadio() bool {
 	return true
 }
 
+func (c *TxPowerCommand) MutatesState() bool {
+	return true
+}
+
+func (c *TxPowerCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
 func (c *TxPowerCommand) Init() error {
 	c.flags = flag.NewFlagSet("txpower", flag.ContinueOnError)
 	c.flags.SetOutput(os.Stdout)
@@ -29,6 +29,14 @@ func (c *UpCommand) NeedsRadio() bool {
 	return true
 }
 
+func (c *UpCommand) MutatesState() bool {
+	return true
+}
+
+func (c *UpCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
 //nolint:errcheck
 func (c *UpCommand) Init() error {
 	c.flags = flag.NewFlagSet("up", flag.ContinueOnError)
@@ -59,6 +67,14 @@ func (c *DownCommand) NeedsRadio() bool {
 	return true
 }
 
+func (c *DownCommand) MutatesState() bool {
+	return true
+}
+
+func (c *DownCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
 //nolint:errcheck
 func (c *DownCommand) Init() error {
 	c.flags = flag.NewFlagSet("down", flag.ContinueOnError)
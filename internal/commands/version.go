@@ -22,6 +22,10 @@ func (c *VersionCommand) NeedsRadio() bool {
 	return false
 }
 
+func (c *VersionCommand) MutatesState() bool {
+	return false
+}
+
 func (c *VersionCommand) Init() error {
 	return nil
 }
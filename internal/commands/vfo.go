@@ -26,6 +26,14 @@ func (c *VFOCommand) NeedsRadio() bool {
 	return true
 }
 
+func (c *VFOCommand) MutatesState() bool {
+	return true
+}
+
+func (c *VFOCommand) Flags() *flag.FlagSet {
+	return c.flags
+}
+
 //nolint:errcheck
 func (c *VFOCommand) Init() error {
 	c.flags = flag.NewFlagSet("vfo", flag.ContinueOnError)
@@ -12,6 +12,28 @@ type (
 		Device  string
 		Pretty  bool
 		NoCheck bool
+		Model   string
+
+		// ReadOnly prevents any command that would change the radio
+		// state from being sent to the radio.
+		ReadOnly bool
+
+		// DryRun prints commands that would change the radio state
+		// instead of sending them.
+		DryRun bool
+
+		// Verify selects whether memory channel and vfo writes are read
+		// back and checked ("off", "strict" or "tolerant").
+		Verify string
+
+		// AllowTransmit is set in a radio profile to permit commands
+		// that key the transmitter.
+		AllowTransmit bool
+
+		// Radio is the name of the radio profile selected from the
+		// configuration file, and ConfigFile is the file it came from.
+		Radio      string
+		ConfigFile string
 	}
 
 	Context struct {
@@ -19,3 +41,14 @@ type (
 		Logger *slog.Logger
 	}
 )
+
+// Defaults returns the configuration used when a setting is not provided
+// on the command line, in the environment, or in a radio profile.
+func Defaults() Config {
+	return Config{
+		Bps:    9600,
+		Vfo:    "0",
+		Device: "/dev/ttyS0",
+		Verify: "off",
+	}
+}
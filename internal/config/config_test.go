@@ -0,0 +1,172 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	flag "github.com/spf13/pflag"
+)
+
+const testConfigFile = `
+default-radio: shack
+radios:
+  shack:
+    device: /dev/ttyUSB0
+    bps: 57600
+    vfo: "1"
+  mobile:
+    device: /dev/ttyUSB1
+    pretty: true
+    read-only: true
+    model: TM-V71
+    allow-transmit: true
+`
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func fakeEnv(env map[string]string) LookupEnvFunc {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
+
+func resolve(t *testing.T, args []string, env map[string]string) (Config, error) {
+	t.Helper()
+	var values Config
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddFlags(flags, &values)
+	if err := flags.Parse(args); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	return Resolve(flags, &values, fakeEnv(env))
+}
+
+func TestResolvePrecedence(t *testing.T) {
+	path := writeConfigFile(t, testConfigFile)
+
+	tests := []struct {
+		name     string
+		args     []string
+		env      map[string]string
+		expected Config
+	}{
+		{
+			name: "default radio profile",
+			args: []string{"--config", path},
+			expected: Config{
+				Device: "/dev/ttyUSB0", Bps: 57600, Vfo: "1", Verify: "off",
+				Radio: "shack", ConfigFile: path,
+			},
+		},
+		{
+			name: "radio selected by flag",
+			args: []string{"--config", path, "--radio", "mobile"},
+			expected: Config{
+				Device: "/dev/ttyUSB1", Bps: 9600, Vfo: "0", Pretty: true, Model: "TM-V71", AllowTransmit: true, ReadOnly: true, Verify: "off",
+				Radio: "mobile", ConfigFile: path,
+			},
+		},
+		{
+			name: "radio and config selected by environment",
+			env:  map[string]string{"KWCTL_CONFIG": path, "KWCTL_RADIO": "mobile"},
+			expected: Config{
+				Device: "/dev/ttyUSB1", Bps: 9600, Vfo: "0", Pretty: true, Model: "TM-V71", AllowTransmit: true, ReadOnly: true, Verify: "off",
+				Radio: "mobile", ConfigFile: path,
+			},
+		},
+		{
+			name: "read-only set by environment",
+			args: []string{"--config", path},
+			env:  map[string]string{"KWCTL_READONLY": "true"},
+			expected: Config{
+				Device: "/dev/ttyUSB0", Bps: 57600, Vfo: "1", ReadOnly: true, Verify: "off",
+				Radio: "shack", ConfigFile: path,
+			},
+		},
+		{
+			name: "verify set by environment",
+			args: []string{"--config", path},
+			env:  map[string]string{"KWCTL_VERIFY": "tolerant"},
+			expected: Config{
+				Device: "/dev/ttyUSB0", Bps: 57600, Vfo: "1", Verify: "tolerant",
+				Radio: "shack", ConfigFile: path,
+			},
+		},
+		{
+			name: "environment overrides profile",
+			args: []string{"--config", path},
+			env:  map[string]string{"KWCTL_DEVICE": "/dev/ttyACM0", "KWCTL_BPS": "19200"},
+			expected: Config{
+				Device: "/dev/ttyACM0", Bps: 19200, Vfo: "1", Verify: "off",
+				Radio: "shack", ConfigFile: path,
+			},
+		},
+		{
+			name: "flags override environment and profile",
+			args: []string{"--config", path, "--device", "/dev/ttyS1", "-v"},
+			env:  map[string]string{"KWCTL_DEVICE": "/dev/ttyACM0", "KWCTL_VFO": "0"},
+			expected: Config{
+				Device: "/dev/ttyS1", Bps: 57600, Vfo: "0", Verbose: 1, Verify: "off",
+				Radio: "shack", ConfigFile: path,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := resolve(t, tt.args, tt.env)
+			if err != nil {
+				t.Fatalf("Resolve() failed: %v", err)
+			}
+			if cfg != tt.expected {
+				t.Errorf("Resolve() = %+v, expected %+v", cfg, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	path := writeConfigFile(t, testConfigFile)
+	invalid := writeConfigFile(t, "radios:\n  shack:\n    devcie: /dev/ttyUSB0\n")
+
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+	}{
+		{
+			name: "unknown radio",
+			args: []string{"--config", path, "--radio", "base"},
+		},
+		{
+			name: "missing config file",
+			args: []string{"--config", filepath.Join(t.TempDir(), "missing.yaml")},
+		},
+		{
+			name: "unknown key in config file",
+			args: []string{"--config", invalid},
+		},
+		{
+			name: "invalid environment value",
+			args: []string{"--config", path},
+			env:  map[string]string{"KWCTL_BPS": "fast"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := resolve(t, tt.args, tt.env); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
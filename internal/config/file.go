@@ -0,0 +1,141 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Profile holds the settings for a single radio. Settings that are
+	// not present in the configuration file are nil.
+	Profile struct {
+		Device        *string `yaml:"device"`
+		Bps           *int    `yaml:"bps"`
+		Vfo           *string `yaml:"vfo"`
+		Pretty        *bool   `yaml:"pretty"`
+		NoCheck       *bool   `yaml:"no-check"`
+		Model         *string `yaml:"model"`
+		AllowTransmit *bool   `yaml:"allow-transmit"`
+		ReadOnly      *bool   `yaml:"read-only"`
+		Verify        *string `yaml:"verify"`
+	}
+
+	// File is the content of the kwctl configuration file.
+	//
+	//	default-radio: shack
+	//	radios:
+	//	  shack:
+	//	    device: /dev/ttyUSB0
+	//	    bps: 57600
+	//	  mobile:
+	//	    device: /dev/ttyUSB1
+	//	    vfo: "1"
+	File struct {
+		DefaultRadio string             `yaml:"default-radio"`
+		Radios       map[string]Profile `yaml:"radios"`
+	}
+)
+
+// DefaultConfigFile returns the path to the configuration file used when
+// none is specified explicitly (e.g. ~/.config/kwctl/config.yaml).
+func DefaultConfigFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "kwctl", "config.yaml")
+}
+
+// LoadFile reads a configuration file. Unknown keys are treated as errors
+// so that typos don't silently select the wrong device.
+func LoadFile(path string) (File, error) {
+	var file File
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return file, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return file, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return file, nil
+}
+
+// Profile returns the named radio profile. If name is empty, the default
+// radio is used; if there is no default radio, an empty profile is returned.
+func (f File) Profile(name string) (Profile, error) {
+	if name == "" {
+		name = f.DefaultRadio
+	}
+	if name == "" {
+		return Profile{}, nil
+	}
+
+	profile, ok := f.Radios[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("no such radio: %s", name)
+	}
+
+	return profile, nil
+}
+
+// Apply overrides cfg with the settings present in the profile.
+func (p Profile) Apply(cfg *Config) {
+	if p.Device != nil {
+		cfg.Device = *p.Device
+	}
+	if p.Bps != nil {
+		cfg.Bps = *p.Bps
+	}
+	if p.Vfo != nil {
+		cfg.Vfo = *p.Vfo
+	}
+	if p.Pretty != nil {
+		cfg.Pretty = *p.Pretty
+	}
+	if p.NoCheck != nil {
+		cfg.NoCheck = *p.NoCheck
+	}
+	if p.Model != nil {
+		cfg.Model = *p.Model
+	}
+	if p.AllowTransmit != nil {
+		cfg.AllowTransmit = *p.AllowTransmit
+	}
+	if p.ReadOnly != nil {
+		cfg.ReadOnly = *p.ReadOnly
+	}
+	if p.Verify != nil {
+		cfg.Verify = *p.Verify
+	}
+}
+
+// loadConfigFile loads the configuration file at path. An empty path
+// selects the default configuration file, which is allowed to be missing.
+func loadConfigFile(path string) (File, error) {
+	if path != "" {
+		return LoadFile(path)
+	}
+
+	path = DefaultConfigFile()
+	if path == "" {
+		return File{}, nil
+	}
+
+	file, err := LoadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return File{}, nil
+	}
+	return file, err
+}
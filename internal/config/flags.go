@@ -0,0 +1,151 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+
+	flag "github.com/spf13/pflag"
+)
+
+// LookupEnvFunc has the signature of os.LookupEnv.
+type LookupEnvFunc func(string) (string, bool)
+
+// envSettings maps KWCTL_* environment variables to configuration settings.
+var envSettings = map[string]func(cfg *Config, value string) error{
+	"KWCTL_DEVICE": func(cfg *Config, value string) error {
+		cfg.Device = value
+		return nil
+	},
+	"KWCTL_BPS": func(cfg *Config, value string) (err error) {
+		cfg.Bps, err = strconv.Atoi(value)
+		return err
+	},
+	"KWCTL_VFO": func(cfg *Config, value string) error {
+		cfg.Vfo = value
+		return nil
+	},
+	"KWCTL_PRETTY": func(cfg *Config, value string) (err error) {
+		cfg.Pretty, err = strconv.ParseBool(value)
+		return err
+	},
+	"KWCTL_NOCHECK": func(cfg *Config, value string) (err error) {
+		cfg.NoCheck, err = strconv.ParseBool(value)
+		return err
+	},
+	"KWCTL_MODEL": func(cfg *Config, value string) error {
+		cfg.Model = value
+		return nil
+	},
+	"KWCTL_VERIFY": func(cfg *Config, value string) error {
+		cfg.Verify = value
+		return nil
+	},
+	"KWCTL_READONLY": func(cfg *Config, value string) (err error) {
+		cfg.ReadOnly, err = strconv.ParseBool(value)
+		return err
+	},
+}
+
+// AddFlags adds the global configuration flags to the provided FlagSet.
+// The flags are bound to values for temporary storage; use ApplyFlags or
+// Resolve to produce the effective configuration.
+func AddFlags(flags *flag.FlagSet, values *Config) {
+	defaults := Defaults()
+	flags.IntVarP(&values.Bps, "bps", "b", defaults.Bps, "serial port speed")
+	flags.CountVarP(&values.Verbose, "verbose", "v", "increase logging verbosity")
+	flags.StringVarP(&values.Vfo, "vfo", "", defaults.Vfo, "select vfo on which to operate")
+	flags.StringVarP(&values.Device, "device", "d", defaults.Device, "serial device")
+	flags.BoolVarP(&values.Pretty, "pretty", "p", defaults.Pretty, "pretty print output")
+	flags.BoolVarP(&values.NoCheck, "no-check", "n", defaults.NoCheck, "Skip radio check")
+	flags.StringVarP(&values.Model, "model", "", defaults.Model, "expected radio model (e.g. TM-V71)")
+	flags.BoolVarP(&values.ReadOnly, "read-only", "", defaults.ReadOnly, "refuse to change radio state")
+	flags.BoolVarP(&values.DryRun, "dry-run", "", defaults.DryRun, "show commands that would change radio state without sending them")
+	flags.StringVarP(&values.Verify, "verify", "", defaults.Verify, "read back channel and vfo writes (off, strict, tolerant)")
+	flags.StringVarP(&values.Radio, "radio", "R", "", "select radio profile from config file")
+	flags.StringVarP(&values.ConfigFile, "config", "c", "", "config file (default "+DefaultConfigFile()+")")
+}
+
+// ApplyFlags applies only the flags that were set (visited) to cfg.
+func ApplyFlags(flags *flag.FlagSet, values *Config, cfg *Config) {
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "bps":
+			cfg.Bps = values.Bps
+		case "verbose":
+			cfg.Verbose = values.Verbose
+		case "vfo":
+			cfg.Vfo = values.Vfo
+		case "device":
+			cfg.Device = values.Device
+		case "pretty":
+			cfg.Pretty = values.Pretty
+		case "no-check":
+			cfg.NoCheck = values.NoCheck
+		case "model":
+			cfg.Model = values.Model
+		case "read-only":
+			cfg.ReadOnly = values.ReadOnly
+		case "dry-run":
+			cfg.DryRun = values.DryRun
+		case "verify":
+			cfg.Verify = values.Verify
+		case "radio":
+			cfg.Radio = values.Radio
+		case "config":
+			cfg.ConfigFile = values.ConfigFile
+		}
+	})
+}
+
+// ApplyEnv applies settings from KWCTL_* environment variables to cfg.
+func ApplyEnv(cfg *Config, lookupEnv LookupEnvFunc) error {
+	for name, apply := range envSettings {
+		value, ok := lookupEnv(name)
+		if !ok || value == "" {
+			continue
+		}
+		if err := apply(cfg, value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Resolve produces the effective configuration from the command line,
+// the environment, and the selected radio profile in the configuration
+// file. Settings are taken from (in order of precedence) flags, KWCTL_*
+// environment variables, the radio profile, and finally Defaults().
+func Resolve(flags *flag.FlagSet, values *Config, lookupEnv LookupEnvFunc) (Config, error) {
+	// Locate the configuration file and radio profile first, since they
+	// determine the base settings.
+	var selected Config
+	selected.ConfigFile, _ = lookupEnv("KWCTL_CONFIG")
+	selected.Radio, _ = lookupEnv("KWCTL_RADIO")
+	ApplyFlags(flags, values, &selected)
+
+	file, err := loadConfigFile(selected.ConfigFile)
+	if err != nil {
+		return Config{}, err
+	}
+
+	profile, err := file.Profile(selected.Radio)
+	if err != nil {
+		return Config{}, err
+	}
+
+	cfg := Defaults()
+	profile.Apply(&cfg)
+	if err := ApplyEnv(&cfg, lookupEnv); err != nil {
+		return Config{}, err
+	}
+	ApplyFlags(flags, values, &cfg)
+
+	cfg.ConfigFile = selected.ConfigFile
+	cfg.Radio = selected.Radio
+	if cfg.Radio == "" {
+		cfg.Radio = file.DefaultRadio
+	}
+
+	return cfg, nil
+}
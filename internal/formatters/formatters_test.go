@@ -7,31 +7,31 @@ import (
 	"github.com/larsks/kwctl/pkg/radio/types"
 )
 
-func TestHeadersFromStruct_WithChannel(t *testing.T) {
+func TestHeadersFromStruct_WithDisplayChannel(t *testing.T) {
 	expected := []string{
 		"Name", "Number", "RxFreq", "RxStep", "Shift", "Reverse",
-		"Tone", "CTCSS", "DCS", "ToneFreq", "CTCSSFreq", "DCSCode",
+		"ToneMode", "ToneFreq", "CTCSSFreq", "DCSCode",
 		"Offset", "Mode", "TxFreq", "TxStep", "Lockout",
 	}
 
-	result := HeadersFromStruct(types.Channel{})
+	result := HeadersFromStruct(types.DisplayChannel{})
 
 	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("HeadersFromStruct(Channel{}) = %v, want %v", result, expected)
+		t.Errorf("HeadersFromStruct(DisplayChannel{}) = %v, want %v", result, expected)
 	}
 }
 
 func TestHeadersFromStruct_WithPointer(t *testing.T) {
 	expected := []string{
 		"Name", "Number", "RxFreq", "RxStep", "Shift", "Reverse",
-		"Tone", "CTCSS", "DCS", "ToneFreq", "CTCSSFreq", "DCSCode",
+		"ToneMode", "ToneFreq", "CTCSSFreq", "DCSCode",
 		"Offset", "Mode", "TxFreq", "TxStep", "Lockout",
 	}
 
-	result := HeadersFromStruct(&types.Channel{})
+	result := HeadersFromStruct(&types.DisplayChannel{})
 
 	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("HeadersFromStruct(&Channel{}) = %v, want %v", result, expected)
+		t.Errorf("HeadersFromStruct(&DisplayChannel{}) = %v, want %v", result, expected)
 	}
 }
 
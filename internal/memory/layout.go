@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+// Sort keys accepted by Sort.
+const (
+	SortByFrequency = "freq"
+	SortByName      = "name"
+)
+
+func used(channels map[int]types.Channel, slot int) bool {
+	return channels[slot].RxFreq != 0
+}
+
+// Move returns the layout that moves the channels in slots src, in order,
+// to consecutive slots starting at dest. Source slots that are not also
+// destinations are cleared. Destination slots must be empty unless they
+// are part of src.
+func Move(channels map[int]types.Channel, src []int, dest int) (Layout, error) {
+	layout := make(Layout)
+
+	for i, source := range src {
+		slot := dest + i
+		if used(channels, slot) && !slices.Contains(src, slot) {
+			return nil, fmt.Errorf("destination channel %03d is in use", slot)
+		}
+		layout[slot] = source
+	}
+
+	for _, source := range src {
+		if !layout.contains(source) {
+			layout[source] = Empty
+		}
+	}
+
+	return layout, nil
+}
+
+// Swap returns the layout that exchanges the channels in slots a with the
+// channels in slots b.
+func Swap(a, b []int) (Layout, error) {
+	if len(a) != len(b) {
+		return nil, fmt.Errorf("cannot swap ranges of different sizes (%d and %d)", len(a), len(b))
+	}
+
+	layout := make(Layout)
+	for i := range a {
+		if slices.Contains(b, a[i]) {
+			return nil, fmt.Errorf("cannot swap overlapping ranges (channel %03d appears in both)", a[i])
+		}
+		layout[a[i]] = b[i]
+		layout[b[i]] = a[i]
+	}
+
+	return layout, nil
+}
+
+// Sort returns the layout that sorts the channels in slots by the given key
+// and packs them at the start of slots, leaving any empty slots at the end.
+func Sort(channels map[int]types.Channel, slots []int, by string) (Layout, error) {
+	var compare func(a, b types.Channel) int
+
+	switch by {
+	case SortByFrequency:
+		compare = func(a, b types.Channel) int {
+			return cmp.Compare(a.RxFreq, b.RxFreq)
+		}
+	case SortByName:
+		compare = func(a, b types.Channel) int {
+			return cmp.Or(
+				cmp.Compare(strings.ToUpper(a.Name), strings.ToUpper(b.Name)),
+				cmp.Compare(a.RxFreq, b.RxFreq),
+			)
+		}
+	default:
+		return nil, fmt.Errorf("invalid sort key: %s", by)
+	}
+
+	var sorted []types.Channel
+	for _, slot := range slots {
+		if used(channels, slot) {
+			channel := channels[slot]
+			channel.Number = slot
+			sorted = append(sorted, channel)
+		}
+	}
+	slices.SortStableFunc(sorted, compare)
+
+	return pack(slots, sorted), nil
+}
+
+// Compact returns the layout that packs the channels in slots at the start
+// of slots, preserving their order and leaving any empty slots at the end.
+func Compact(channels map[int]types.Channel, slots []int) Layout {
+	var packed []types.Channel
+	for _, slot := range slots {
+		if used(channels, slot) {
+			channel := channels[slot]
+			channel.Number = slot
+			packed = append(packed, channel)
+		}
+	}
+
+	return pack(slots, packed)
+}
+
+// Insert returns the layout that frees slot by shifting it and the channels
+// after it down by one, up to the first empty slot at or before end.
+func Insert(channels map[int]types.Channel, slot, end int) (Layout, error) {
+	gap := slot
+	for gap <= end && used(channels, gap) {
+		gap++
+	}
+	if gap > end {
+		return nil, fmt.Errorf("no empty channel between %03d and %03d", slot, end)
+	}
+
+	layout := make(Layout)
+	for i := slot; i < gap; i++ {
+		layout[i+1] = i
+	}
+	if gap > slot {
+		layout[slot] = Empty
+	}
+
+	return layout, nil
+}
+
+// pack assigns channels (identified by their Number) to slots in order,
+// clearing any remaining slots.
+func pack(slots []int, channels []types.Channel) Layout {
+	layout := make(Layout)
+	for i, slot := range slots {
+		if i < len(channels) {
+			layout[slot] = channels[i].Number
+		} else {
+			layout[slot] = Empty
+		}
+	}
+	return layout
+}
@@ -0,0 +1,168 @@
+// Package memory plans the reorganisation of radio memory channels.
+//
+// A reorganisation is described by the desired layout of a set of memory
+// slots: for each slot, the slot whose current content should end up
+// there (or nothing, if the slot should end up empty). Planning turns the
+// layout into an ordered list of writes and clears such that, if applying
+// the plan is interrupted, the content of every original channel is still
+// present somewhere in memory.
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+type (
+	// Layout maps destination slots to source slots. A source of Empty
+	// means the destination should be cleared. Slots that are not in the
+	// layout are left alone.
+	Layout map[int]int
+
+	// Step is a single operation in a plan. If Clear is true, the slot
+	// Dest is cleared; otherwise Channel (originally from slot Source) is
+	// written to Dest.
+	Step struct {
+		Dest    int
+		Source  int
+		Clear   bool
+		Channel types.Channel
+	}
+
+	Plan []Step
+)
+
+// Empty is used as the source for slots that should be cleared.
+const Empty = -1
+
+// ErrNeedScratch is returned by MakePlan when the plan requires a scratch
+// channel but none was provided.
+var ErrNeedScratch = errors.New("plan requires a scratch channel")
+
+func (s Step) String() string {
+	if s.Clear {
+		return fmt.Sprintf("clear %03d", s.Dest)
+	}
+	return fmt.Sprintf("write %03d <- %03d %s", s.Dest, s.Source, s.Channel)
+}
+
+func (p Plan) String() string {
+	var lines []string
+	for i, step := range p {
+		lines = append(lines, fmt.Sprintf("%3d. %s", i+1, step))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// MakePlan produces an ordered list of steps that transforms memory from
+// its current content (channels, indexed by slot, empty slots omitted or
+// with RxFreq 0) into the given layout.
+//
+// A slot is only overwritten once its current content has been written to
+// every destination that needs it. Cycles (e.g. swapping two channels) are
+// broken by first copying one channel to the scratch slot, which must be
+// empty and outside the layout; pass Empty if no scratch slot is
+// available, in which case plans containing cycles are rejected.
+func MakePlan(channels map[int]types.Channel, layout Layout, scratch int) (Plan, error) {
+	used := func(slot int) bool {
+		return channels[slot].RxFreq != 0
+	}
+
+	// pending maps each destination to the slot holding the content it
+	// needs. Destinations that already hold the right content, or that
+	// are already empty and should stay empty, need no work.
+	pending := make(map[int]int)
+	for dest, source := range layout {
+		if source != Empty && !used(source) {
+			source = Empty
+		}
+		if source == dest || (source == Empty && !used(dest)) {
+			continue
+		}
+		pending[dest] = source
+	}
+
+	// location tracks where the original content of a slot can be found.
+	location := make(map[int]int)
+	for _, source := range pending {
+		if source != Empty {
+			location[source] = source
+		}
+	}
+
+	var plan Plan
+	scratchUsed := false
+
+	// readers returns true if some pending step still needs the content
+	// currently stored in slot.
+	readers := func(slot int) bool {
+		for dest, source := range pending {
+			if dest != slot && source != Empty && location[source] == slot {
+				return true
+			}
+		}
+		return false
+	}
+
+	for len(pending) > 0 {
+		progress := false
+
+		for _, dest := range slices.Sorted(maps.Keys(pending)) {
+			if readers(dest) {
+				continue
+			}
+
+			source := pending[dest]
+			if source == Empty {
+				plan = append(plan, Step{Dest: dest, Source: Empty, Clear: true})
+			} else {
+				channel := channels[source]
+				channel.Number = dest
+				plan = append(plan, Step{Dest: dest, Source: location[source], Channel: channel})
+			}
+			delete(pending, dest)
+			progress = true
+		}
+
+		if progress {
+			continue
+		}
+
+		// Every remaining destination holds content that another step
+		// still needs, so the remaining steps form one or more cycles.
+		// Save one channel to the scratch slot to break a cycle.
+		switch {
+		case scratch == Empty:
+			return nil, ErrNeedScratch
+		case used(scratch):
+			return nil, fmt.Errorf("scratch channel %03d is not empty", scratch)
+		case layout.contains(scratch):
+			return nil, fmt.Errorf("scratch channel %03d is part of the reorganisation", scratch)
+		case readers(scratch):
+			return nil, fmt.Errorf("unable to resolve plan")
+		}
+
+		dest := slices.Min(slices.Collect(maps.Keys(pending)))
+		channel := channels[dest]
+		channel.Number = scratch
+		plan = append(plan, Step{Dest: scratch, Source: dest, Channel: channel})
+		location[dest] = scratch
+		scratchUsed = true
+	}
+
+	if scratchUsed {
+		plan = append(plan, Step{Dest: scratch, Source: Empty, Clear: true})
+	}
+
+	return plan, nil
+}
+
+func (l Layout) contains(slot int) bool {
+	_, exists := l[slot]
+	return exists
+}
@@ -0,0 +1,239 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+// testMemory creates memory with a channel in each of the given slots. The
+// frequency of each channel identifies its original slot.
+func testMemory(slots ...int) map[int]types.Channel {
+	channels := make(map[int]types.Channel)
+	for _, slot := range slots {
+		channels[slot] = types.Channel{Number: slot, RxFreq: 144_000_000 + slot*10_000}
+	}
+	return channels
+}
+
+// applyPlan simulates applying a plan, verifying after every step that the
+// content of every original channel is still present in memory.
+func applyPlan(t *testing.T, channels map[int]types.Channel, plan Plan) map[int]types.Channel {
+	t.Helper()
+
+	memory := make(map[int]types.Channel)
+	for slot, channel := range channels {
+		if channel.RxFreq != 0 {
+			memory[slot] = channel
+		}
+	}
+
+	for i, step := range plan {
+		if step.Clear {
+			delete(memory, step.Dest)
+		} else {
+			if step.Channel.Number != step.Dest {
+				t.Fatalf("step %d: channel number %d does not match destination %d", i+1, step.Channel.Number, step.Dest)
+			}
+			memory[step.Dest] = step.Channel
+		}
+
+		for slot, original := range channels {
+			if original.RxFreq == 0 {
+				continue
+			}
+			found := false
+			for _, channel := range memory {
+				if channel.RxFreq == original.RxFreq {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Fatalf("step %d (%s): content of channel %03d lost", i+1, step, slot)
+			}
+		}
+	}
+
+	return memory
+}
+
+// checkLayout verifies that memory holds the content described by layout.
+func checkLayout(t *testing.T, original, memory map[int]types.Channel, layout Layout) {
+	t.Helper()
+
+	for dest, source := range layout {
+		have, exists := memory[dest]
+		if source == Empty || original[source].RxFreq == 0 {
+			if exists {
+				t.Errorf("channel %03d: expected empty, found content of %d", dest, have.RxFreq)
+			}
+			continue
+		}
+		if !exists || have.RxFreq != original[source].RxFreq {
+			t.Errorf("channel %03d: expected content of %03d", dest, source)
+		}
+	}
+}
+
+func TestMakePlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		channels map[int]types.Channel
+		layout   func(map[int]types.Channel) (Layout, error)
+		scratch  int
+	}{
+		{
+			name:     "move to empty slots",
+			channels: testMemory(1, 2, 3),
+			layout: func(c map[int]types.Channel) (Layout, error) {
+				return Move(c, []int{1, 2, 3}, 100)
+			},
+			scratch: Empty,
+		},
+		{
+			name:     "move to overlapping range",
+			channels: testMemory(1, 2, 3),
+			layout: func(c map[int]types.Channel) (Layout, error) {
+				return Move(c, []int{1, 2, 3}, 2)
+			},
+			scratch: Empty,
+		},
+		{
+			name:     "swap",
+			channels: testMemory(1, 2),
+			layout: func(map[int]types.Channel) (Layout, error) {
+				return Swap([]int{1}, []int{2})
+			},
+			scratch: 999,
+		},
+		{
+			name:     "swap ranges with empty slots",
+			channels: testMemory(1, 2, 11),
+			layout: func(map[int]types.Channel) (Layout, error) {
+				return Swap([]int{1, 2, 3}, []int{10, 11, 12})
+			},
+			scratch: 999,
+		},
+		{
+			name: "sort by frequency",
+			channels: map[int]types.Channel{
+				1: {Number: 1, RxFreq: 446_000_000},
+				2: {Number: 2, RxFreq: 146_520_000},
+				4: {Number: 4, RxFreq: 145_000_000},
+				5: {Number: 5, RxFreq: 147_000_000},
+			},
+			layout: func(c map[int]types.Channel) (Layout, error) {
+				return Sort(c, []int{1, 2, 3, 4, 5}, SortByFrequency)
+			},
+			scratch: 999,
+		},
+		{
+			name: "sort by name",
+			channels: map[int]types.Channel{
+				1: {Number: 1, RxFreq: 446_000_000, Name: "charlie"},
+				2: {Number: 2, RxFreq: 146_520_000, Name: "ALPHA"},
+				3: {Number: 3, RxFreq: 145_000_000, Name: "bravo"},
+			},
+			layout: func(c map[int]types.Channel) (Layout, error) {
+				return Sort(c, []int{1, 2, 3}, SortByName)
+			},
+			scratch: 999,
+		},
+		{
+			name:     "compact",
+			channels: testMemory(1, 3, 6, 7),
+			layout: func(c map[int]types.Channel) (Layout, error) {
+				return Compact(c, []int{1, 2, 3, 4, 5, 6, 7}), nil
+			},
+			scratch: Empty,
+		},
+		{
+			name:     "insert",
+			channels: testMemory(10, 11, 12, 14),
+			layout: func(c map[int]types.Channel) (Layout, error) {
+				return Insert(c, 10, 20)
+			},
+			scratch: Empty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout, err := tt.layout(tt.channels)
+			if err != nil {
+				t.Fatalf("failed to create layout: %v", err)
+			}
+
+			plan, err := MakePlan(tt.channels, layout, tt.scratch)
+			if err != nil {
+				t.Fatalf("MakePlan() failed: %v", err)
+			}
+
+			memory := applyPlan(t, tt.channels, plan)
+			checkLayout(t, tt.channels, memory, layout)
+			if tt.scratch != Empty {
+				if _, exists := memory[tt.scratch]; exists {
+					t.Errorf("scratch channel was not cleared")
+				}
+			}
+		})
+	}
+}
+
+func TestMakePlanNeedsScratch(t *testing.T) {
+	channels := testMemory(1, 2)
+	layout, err := Swap([]int{1}, []int{2})
+	if err != nil {
+		t.Fatalf("failed to create layout: %v", err)
+	}
+
+	if _, err := MakePlan(channels, layout, Empty); !errors.Is(err, ErrNeedScratch) {
+		t.Errorf("expected ErrNeedScratch, got %v", err)
+	}
+
+	if _, err := MakePlan(testMemory(1, 2, 999), layout, 999); err == nil {
+		t.Errorf("expected error for scratch channel in use")
+	}
+}
+
+func TestSortOrder(t *testing.T) {
+	channels := map[int]types.Channel{
+		1: {Number: 1, RxFreq: 446_000_000},
+		3: {Number: 3, RxFreq: 146_520_000},
+		4: {Number: 4, RxFreq: 145_000_000},
+	}
+
+	layout, err := Sort(channels, []int{1, 2, 3, 4}, SortByFrequency)
+	if err != nil {
+		t.Fatalf("Sort() failed: %v", err)
+	}
+
+	expected := Layout{1: 4, 2: 3, 3: 1, 4: Empty}
+	for slot, source := range expected {
+		if layout[slot] != source {
+			t.Errorf("slot %d: have %d, expected %d", slot, layout[slot], source)
+		}
+	}
+}
+
+func TestLayoutErrors(t *testing.T) {
+	channels := testMemory(1, 2, 10, 20, 21, 22)
+
+	if _, err := Move(channels, []int{1, 2}, 9); err == nil {
+		t.Errorf("Move: expected error for destination in use")
+	}
+	if _, err := Swap([]int{1, 2}, []int{10}); err == nil {
+		t.Errorf("Swap: expected error for different sizes")
+	}
+	if _, err := Swap([]int{1, 2}, []int{2, 3}); err == nil {
+		t.Errorf("Swap: expected error for overlapping ranges")
+	}
+	if _, err := Sort(channels, []int{1, 2}, "color"); err == nil {
+		t.Errorf("Sort: expected error for invalid key")
+	}
+	if _, err := Insert(channels, 20, 22); err == nil {
+		t.Errorf("Insert: expected error when there is no empty slot")
+	}
+}
@@ -0,0 +1,85 @@
+package radio
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// overlayUnavailable marks an overlay entry that reads as unavailable (for
+// example, a memory channel that has been cleared).
+const overlayUnavailable = "N"
+
+// WithDryRun configures the radio to print state-changing commands to out
+// rather than sending them. Writes are recorded in an in-memory overlay so
+// that reading the same setting back returns the value that would have
+// been written; all other reads go to the radio. If out is nil, dry run
+// mode is disabled.
+func (r *Radio) WithDryRun(out io.Writer) *Radio {
+	r.dryRun = out
+	r.overlay = make(map[string]string)
+	return r
+}
+
+// overlayKey identifies the setting addressed by a command: the command
+// name, plus the first argument for commands that select a channel or band.
+func overlayKey(cmd string, fields []string) string {
+	cmd = strings.ToUpper(cmd)
+	if readArgs[cmd] >= 1 && len(fields) > 0 {
+		return cmd + " " + fields[0]
+	}
+	return cmd
+}
+
+// dryRunCommand handles a command in dry run mode. It returns handled ==
+// false for reads that must be sent to the radio.
+func (r *Radio) dryRunCommand(cmd string, args []string) (res string, handled bool, err error) {
+	var fields []string
+	joined := strings.Join(args, ",")
+	if len(args) > 0 {
+		fields = strings.Split(joined, ",")
+	}
+	key := overlayKey(cmd, fields)
+
+	if !IsWriteCommand(cmd, args...) {
+		value, exists := r.overlay[key]
+		switch {
+		case !exists:
+			return "", false, nil
+		case value == overlayUnavailable:
+			return "", true, ErrUnavailableCommand
+		default:
+			r.logger.Debug("dry run: read from overlay", "cmd", cmd, "response", value)
+			return value, true, nil
+		}
+	}
+
+	command := cmd
+	if len(args) > 0 {
+		command = fmt.Sprintf("%s %s", cmd, joined)
+	}
+	fmt.Fprintf(r.dryRun, "dry-run: %s\n", command) //nolint:errcheck
+
+	n, known := readArgs[strings.ToUpper(cmd)]
+	switch {
+	case !known || n == alwaysWrites:
+		// Nothing to record.
+	case key != strings.ToUpper(cmd) && fields[len(fields)-1] == "C" && strings.EqualFold(cmd, "ME"):
+		r.overlay[key] = overlayUnavailable
+		r.overlay["MN "+fields[0]] = overlayUnavailable
+	default:
+		// Write commands take the same arguments that the corresponding
+		// read returns.
+		r.overlay[key] = joined
+		// A channel written without a name reads back with an empty name,
+		// rather than sending the read to the radio, which would report
+		// a channel that was empty as unavailable.
+		if strings.EqualFold(cmd, "ME") {
+			if name, exists := r.overlay["MN "+fields[0]]; !exists || name == overlayUnavailable {
+				r.overlay["MN "+fields[0]] = fields[0] + ","
+			}
+		}
+	}
+
+	return "", true, nil
+}
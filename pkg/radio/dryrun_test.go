@@ -0,0 +1,95 @@
+package radio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+func TestDryRun(t *testing.T) {
+	// The port is never opened, so any command that reaches the radio
+	// will panic.
+	var out bytes.Buffer
+	r := NewRadio("/dev/null", 9600).WithDryRun(&out)
+
+	channel := types.Channel{Name: "CALL", Number: 5, RxFreq: 146520000, ToneFreq: 8, CTCSSFreq: 8}
+	if err := r.SetMemoryChannel(channel); err != nil {
+		t.Fatalf("SetMemoryChannel() failed: %v", err)
+	}
+
+	expected := "dry-run: ME 005,0146520000,0,0,0,0,0,0,08,08,000,00000000,0,0000000000,0,0\ndry-run: MN 005,CALL\n"
+	if out.String() != expected {
+		t.Errorf("unexpected output:\n%s\nexpected:\n%s", out.String(), expected)
+	}
+
+	have, err := r.GetMemoryChannel(5)
+	if err != nil {
+		t.Fatalf("GetMemoryChannel() failed: %v", err)
+	}
+	if have != channel {
+		t.Errorf("have %+v, expected %+v", have, channel)
+	}
+
+	if err := r.ClearMemoryChannel(5); err != nil {
+		t.Fatalf("ClearMemoryChannel() failed: %v", err)
+	}
+	if _, err := r.GetMemoryChannel(5); !errors.Is(err, ErrUnavailableCommand) {
+		t.Errorf("expected ErrUnavailableCommand after clear, got %v", err)
+	}
+
+	channel.Name = ""
+	if err := r.SetMemoryChannel(channel); err != nil {
+		t.Fatalf("SetMemoryChannel() failed: %v", err)
+	}
+	if have, err := r.GetMemoryChannel(5); err != nil || have != channel {
+		t.Errorf("have %+v (%v), expected %+v", have, err, channel)
+	}
+
+	if err := r.SetBandMode(types.BAND_MODE_SINGLE); err != nil {
+		t.Fatalf("SetBandMode() failed: %v", err)
+	}
+	if mode, err := r.GetBandMode(); err != nil || mode != types.BAND_MODE_SINGLE {
+		t.Errorf("have %s (%v), expected single", mode, err)
+	}
+}
+
+func TestDryRunNewChannel(t *testing.T) {
+	// Writing a channel that was never used (and has no name) must read
+	// back from the overlay; the port is never opened.
+	var out bytes.Buffer
+	r := NewRadio("/dev/null", 9600).WithDryRun(&out).WithVerify(VerifyStrict)
+
+	channel := types.Channel{Number: 200, RxFreq: 146520000, ToneFreq: 8, CTCSSFreq: 8}
+	if err := r.SetMemoryChannel(channel); err != nil {
+		t.Fatalf("SetMemoryChannel() failed: %v", err)
+	}
+
+	have, err := r.GetMemoryChannel(200)
+	if err != nil {
+		t.Fatalf("GetMemoryChannel() failed: %v", err)
+	}
+	if have != channel {
+		t.Errorf("have %+v, expected %+v", have, channel)
+	}
+}
+
+func TestDryRunRawCommand(t *testing.T) {
+	r := NewRadio("/dev/null", 9600).WithDryRun(io.Discard)
+
+	if err := r.SetBandMode(types.BAND_MODE_SINGLE); err != nil {
+		t.Fatalf("SetBandMode() failed: %v", err)
+	}
+	if line, err := r.SendRawCommand("dl"); err != nil || line != "DL 1" {
+		t.Errorf("have %q (%v), expected %q", line, err, "DL 1")
+	}
+
+	if err := r.ClearMemoryChannel(7); err != nil {
+		t.Fatalf("ClearMemoryChannel() failed: %v", err)
+	}
+	if line, err := r.SendRawCommand("ME", "007"); err != nil || line != "N" {
+		t.Errorf("have %q (%v), expected N", line, err)
+	}
+}
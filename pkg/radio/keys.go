@@ -0,0 +1,91 @@
+package radio
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+// PressKey emulates pressing a single key. UP and DOWN act on the control
+// band, so the given vfo is made the control band first; VFO, MR and CALL
+// switch the given vfo to the corresponding mode.
+// Digit keys are only meaningful after MR, so they can only be used in a
+// sequence (see PressKeys).
+func (r *Radio) PressKey(vfo string, key types.Key) error {
+	var err error
+
+	switch key {
+	case types.KEY_UP:
+		if err = r.selectControlBand(vfo); err == nil {
+			err = r.MicUp()
+		}
+	case types.KEY_DOWN:
+		if err = r.selectControlBand(vfo); err == nil {
+			err = r.MicDown()
+		}
+	case types.KEY_VFO:
+		err = r.SetVFOMode(vfo, types.VFO_MODE_VFO)
+	case types.KEY_MR:
+		err = r.SetVFOMode(vfo, types.VFO_MODE_MEMORY)
+	case types.KEY_CALL:
+		err = r.SetVFOMode(vfo, types.VFO_MODE_CALL)
+	default:
+		if key.IsDigit() {
+			return fmt.Errorf("digit keys must follow MR")
+		}
+		return fmt.Errorf("invalid key: %d", key)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to press %s: %w", key, err)
+	}
+	return nil
+}
+
+// selectControlBand makes vfo the control band, if it is not already.
+func (r *Radio) selectControlBand(vfo string) error {
+	band, err := strconv.Atoi(vfo)
+	if err != nil {
+		return fmt.Errorf("invalid vfo: %s", vfo)
+	}
+	ctlBand, err := r.GetControlBand()
+	if err != nil || ctlBand == band {
+		return err
+	}
+	return r.SetControlBand(band)
+}
+
+// PressKeys emulates pressing a sequence of keys, waiting for delay between
+// key presses. As on the radio, up to three digits following MR select a
+// memory channel, so "MR 1 4 6" selects channel 146.
+func (r *Radio) PressKeys(vfo string, keys []types.Key, delay time.Duration) error {
+	for i := 0; i < len(keys); i++ {
+		if i > 0 {
+			time.Sleep(delay)
+		}
+
+		if err := r.PressKey(vfo, keys[i]); err != nil {
+			return err
+		}
+		if keys[i] != types.KEY_MR {
+			continue
+		}
+
+		channel, digits := 0, 0
+		for digits < 3 && i+1 < len(keys) && keys[i+1].IsDigit() {
+			i++
+			channel = channel*10 + int(keys[i])
+			digits++
+		}
+		if digits > 0 {
+			time.Sleep(delay)
+			if err := r.SetCurrentChannel(vfo, channel); err != nil {
+				return fmt.Errorf("failed to select channel %03d: %w", channel, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package radio
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+func TestPressKeys(t *testing.T) {
+	var out bytes.Buffer
+	r := NewRadio("/dev/null", 9600).WithDryRun(&out)
+
+	// Band 0 has control, so it is not selected again
+	if _, err := r.SendCommand("BC", "0", "0"); err != nil {
+		t.Fatalf("SendCommand() failed: %v", err)
+	}
+	out.Reset()
+
+	keys, err := types.ParseKeySequence([]string{"mr", "146", "up", "vfo", "down", "call"})
+	if err != nil {
+		t.Fatalf("ParseKeySequence() failed: %v", err)
+	}
+	if err := r.PressKeys("0", keys, 0); err != nil {
+		t.Fatalf("PressKeys() failed: %v", err)
+	}
+
+	expected := "dry-run: VM 0,1\ndry-run: MR 0,146\ndry-run: UP\ndry-run: VM 0,0\ndry-run: DW\ndry-run: VM 0,2\n"
+	if out.String() != expected {
+		t.Errorf("unexpected output:\n%s\nexpected:\n%s", out.String(), expected)
+	}
+
+	if err := r.PressKeys("0", []types.Key{types.KEY_VFO, types.KEY_1}, 0); err == nil {
+		t.Errorf("expected error for digit without MR")
+	}
+}
+
+func TestPressKeysControlBand(t *testing.T) {
+	var out bytes.Buffer
+	r := NewRadio("/dev/null", 9600).WithDryRun(&out)
+
+	if _, err := r.SendCommand("BC", "0", "0"); err != nil {
+		t.Fatalf("SendCommand() failed: %v", err)
+	}
+	out.Reset()
+
+	// UP must step band 1, where the channel was selected
+	keys := []types.Key{types.KEY_MR, types.KEY_5, types.KEY_UP, types.KEY_UP}
+	if err := r.PressKeys("1", keys, 0); err != nil {
+		t.Fatalf("PressKeys() failed: %v", err)
+	}
+
+	expected := "dry-run: VM 1,1\ndry-run: MR 1,005\ndry-run: BC 1,0\ndry-run: UP\ndry-run: UP\n"
+	if out.String() != expected {
+		t.Errorf("unexpected output:\n%s\nexpected:\n%s", out.String(), expected)
+	}
+}
@@ -3,8 +3,10 @@ package radio
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"path"
 	"slices"
 	"strconv"
 	"strings"
@@ -18,19 +20,45 @@ import (
 
 type (
 	Radio struct {
-		device string
-		config *serial.Mode
-		port   serial.Port
-		logger *slog.Logger
+		device   string
+		config   *serial.Mode
+		port     serial.Port
+		logger   *slog.Logger
+		model    string
+		readOnly bool
+		dryRun   io.Writer
+		overlay  map[string]string
+		verify   VerifyMode
+	}
+
+	// AmbiguousChannelError is returned when a channel name matches more
+	// than one memory channel.
+	AmbiguousChannelError struct {
+		Name     string
+		Channels []types.Channel
 	}
 )
 
 var (
 	ErrInvalidCommand     = errors.New("invalid command")
 	ErrUnavailableCommand = errors.New("command unavailable")
+	ErrNoSuchChannel      = errors.New("no such channel")
 	SupportedRadios       = []string{"TM-V71"}
 )
 
+const (
+	// Memory channels are numbered 0 through MaxChannel.
+	MaxChannel = 999
+)
+
+func (e *AmbiguousChannelError) Error() string {
+	var matches []string
+	for _, channel := range e.Channels {
+		matches = append(matches, fmt.Sprintf("%03d (%s)", channel.Number, channel.Name))
+	}
+	return fmt.Sprintf("channel name %q is ambiguous: matches %s", e.Name, strings.Join(matches, ", "))
+}
+
 func NewRadio(device string, bitrate int) *Radio {
 	return &Radio{
 		device: device,
@@ -49,6 +77,13 @@ func (r *Radio) WithLogger(logger *slog.Logger) *Radio {
 	return r
 }
 
+// WithModel restricts Check to a specific radio model, rather than any
+// of the SupportedRadios.
+func (r *Radio) WithModel(model string) *Radio {
+	r.model = model
+	return r
+}
+
 func (r *Radio) Open() error {
 	port, err := serial.Open(r.device, r.config)
 	if err != nil {
@@ -92,7 +127,53 @@ func (r *Radio) drainWithRetry() error {
 	return fmt.Errorf("drain failed after %d retries", maxRetries)
 }
 
+// SendCommand sends a command to the radio and returns the arguments of
+// the response. The radio's "?" and "N" responses are returned as
+// ErrInvalidCommand and ErrUnavailableCommand.
 func (r *Radio) SendCommand(cmd string, args ...string) (string, error) {
+	responseStr, err := r.SendRawCommand(cmd, args...)
+	if err != nil {
+		return "", err
+	}
+
+	// Parse response (format: "CMD ARG1,ARG2,...")
+	if responseStr == "?" {
+		return "", ErrInvalidCommand
+	}
+	if responseStr == "N" {
+		return "", ErrUnavailableCommand
+	}
+	parts := strings.SplitN(responseStr, " ", 2)
+	if len(parts) < 2 {
+		return "", nil
+	}
+
+	r.logger.Debug("parsed response", "response", parts[1])
+	return parts[1], nil
+}
+
+// SendRawCommand sends a command to the radio and returns the response line
+// exactly as it was received, without the trailing carriage return. In dry
+// run mode, a read served from the overlay is returned as the line the radio
+// would have sent, and a write returns an empty line.
+func (r *Radio) SendRawCommand(cmd string, args ...string) (string, error) {
+	if r.readOnly && IsWriteCommand(cmd, args...) {
+		return "", fmt.Errorf("%w: refusing to send %s command", ErrReadOnly, cmd)
+	}
+
+	if r.dryRun != nil {
+		if res, handled, err := r.dryRunCommand(cmd, args); handled {
+			switch {
+			case errors.Is(err, ErrUnavailableCommand):
+				return "N", nil
+			case err != nil || res == "":
+				return "", err
+			default:
+				return fmt.Sprintf("%s %s", strings.ToUpper(cmd), res), nil
+			}
+		}
+	}
+
 	// Step 1: Clear the serial port by sending a carriage return and discarding response
 	if _, err := r.port.Write([]byte("\r")); err != nil {
 		return "", fmt.Errorf("failed to clear serial port: %w", err)
@@ -151,23 +232,9 @@ func (r *Radio) SendCommand(cmd string, args ...string) (string, error) {
 		}
 	}
 
-	// Step 4: Parse response (format: "CMD ARG1,ARG2,...")
 	responseStr := string(response)
 	r.logger.Debug("raw response", "response", responseStr)
-
-	if responseStr == "?" {
-		return "", ErrInvalidCommand
-	}
-	if responseStr == "N" {
-		return "", ErrUnavailableCommand
-	}
-	parts := strings.SplitN(responseStr, " ", 2)
-	if len(parts) < 2 {
-		return "", nil
-	}
-
-	r.logger.Debug("parsed response", "response", parts[1])
-	return parts[1], nil
+	return responseStr, nil
 }
 
 // Ensure that we are communicating with a supported radio.
@@ -177,6 +244,10 @@ func (r *Radio) Check() error {
 		return fmt.Errorf("failed to identify radio at %s: %w", r.device, err)
 	}
 
+	if r.model != "" && id != r.model {
+		return fmt.Errorf("expected %s radio, found %s", r.model, id)
+	}
+
 	if !slices.Contains(SupportedRadios, id) {
 		return fmt.Errorf("unsupported radio: %s", id)
 	}
@@ -197,17 +268,12 @@ func (r *Radio) ClearMemoryChannel(channelNumber int) error {
 func (r *Radio) GetMemoryChannel(channelNumber int) (types.Channel, error) {
 	channelString := fmt.Sprintf("%03d", channelNumber)
 
-	res, err := r.SendCommand("MN", channelString)
+	channelName, err := r.GetMemoryChannelName(channelNumber)
 	if err != nil {
-		return types.EmptyChannel, fmt.Errorf("failed to get name for channel %d: %w", channelNumber, err)
-	}
-	parts := strings.SplitN(res, ",", 2)
-	if len(parts) != 2 {
-		return types.EmptyChannel, fmt.Errorf("invalid response for channel %d", channelNumber)
+		return types.EmptyChannel, err
 	}
-	channelName := parts[1]
 
-	res, err = r.SendCommand("ME", channelString)
+	res, err := r.SendCommand("ME", channelString)
 	if err != nil {
 		return types.EmptyChannel, fmt.Errorf("failed to read data for channel %d: %w", channelNumber, err)
 	}
@@ -222,6 +288,85 @@ func (r *Radio) GetMemoryChannel(channelNumber int) (types.Channel, error) {
 	return channel, nil
 }
 
+// GetMemoryChannelName returns the name of a memory channel.
+func (r *Radio) GetMemoryChannelName(channelNumber int) (string, error) {
+	res, err := r.SendCommand("MN", fmt.Sprintf("%03d", channelNumber))
+	if err != nil {
+		return "", fmt.Errorf("failed to get name for channel %d: %w", channelNumber, err)
+	}
+	parts := strings.SplitN(res, ",", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("invalid response for channel %d", channelNumber)
+	}
+
+	return parts[1], nil
+}
+
+// FindMemoryChannels scans memory for channels with names matching pattern.
+// Matching is case-insensitive and pattern may contain glob wildcards (see
+// path.Match). Empty channels are ignored.
+func (r *Radio) FindMemoryChannels(pattern string) ([]types.Channel, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	var channels []types.Channel
+	for channelNumber := range MaxChannel + 1 {
+		name, err := r.GetMemoryChannelName(channelNumber)
+		if err != nil {
+			if errors.Is(err, ErrUnavailableCommand) {
+				continue
+			}
+			return nil, err
+		}
+
+		if !types.MatchChannelName(pattern, name) {
+			continue
+		}
+
+		channel, err := r.GetMemoryChannel(channelNumber)
+		if err != nil {
+			if errors.Is(err, ErrUnavailableCommand) {
+				continue
+			}
+			return nil, err
+		}
+		channels = append(channels, channel)
+	}
+
+	return channels, nil
+}
+
+// ResolveMemoryChannel returns the number of the memory channel identified
+// by spec, which is either a channel number or a channel name. A name must
+// match exactly one channel (ignoring case).
+func (r *Radio) ResolveMemoryChannel(spec string) (int, error) {
+	if channelNumber, err := strconv.Atoi(spec); err == nil {
+		if channelNumber < 0 || channelNumber > MaxChannel {
+			return 0, fmt.Errorf("invalid channel number %d (channels must be between 0 and %d)", channelNumber, MaxChannel)
+		}
+		return channelNumber, nil
+	}
+
+	// Escape glob metacharacters so that the name is matched literally.
+	pattern := strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`).Replace(spec)
+	channels, err := r.FindMemoryChannels(pattern)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find channel %q: %w", spec, err)
+	}
+
+	switch len(channels) {
+	case 0:
+		return 0, fmt.Errorf("%q: %w", spec, ErrNoSuchChannel)
+	case 1:
+		return channels[0].Number, nil
+	default:
+		return 0, &AmbiguousChannelError{Name: spec, Channels: channels}
+	}
+}
+
+// SetMemoryChannel writes a memory channel, including its name. An empty
+// name clears any name the channel had before.
 func (r *Radio) SetMemoryChannel(channel types.Channel) error {
 	channelString := fmt.Sprintf("%03d", channel.Number)
 	_, err := r.SendCommand("ME", channel.Serialize())
@@ -229,11 +374,19 @@ func (r *Radio) SetMemoryChannel(channel types.Channel) error {
 		return fmt.Errorf("failed to set channel %d: %w", channel.Number, err)
 	}
 
-	if channel.Name != "" {
-		_, err := r.SendCommand("MN", channelString, strings.ToUpper(channel.Name))
+	// Compare the read-back against the name that was actually sent.
+	written := channel
+	written.Name = strings.ToUpper(channel.Name)
+	if _, err := r.SendCommand("MN", channelString, written.Name); err != nil {
+		return fmt.Errorf("failed to set name for channel %d: %w", channel.Number, err)
+	}
+
+	if r.verify != VerifyOff {
+		stored, err := r.GetMemoryChannel(channel.Number)
 		if err != nil {
-			return fmt.Errorf("failed to set name for channel %d: %w", channel.Number, err)
+			return fmt.Errorf("failed to verify channel %d: %w", channel.Number, err)
 		}
+		return r.checkWrite(fmt.Sprintf("channel %03d", channel.Number), written.Diff(stored))
 	}
 
 	return nil
@@ -299,6 +452,40 @@ func (r *Radio) SetVFO(vfo string, config types.VFO) error {
 		return fmt.Errorf("failed to tune vfo %s: %w", vfo, err)
 	}
 
+	if r.verify != VerifyOff {
+		stored, err := r.GetVFO(vfo)
+		if err != nil {
+			return fmt.Errorf("failed to verify vfo %s: %w", vfo, err)
+		}
+		return r.checkWrite("vfo "+vfo, config.Diff(stored))
+	}
+
+	return nil
+}
+
+// GetCallChannel returns the configuration of the call channel for the
+// given band.
+func (r *Radio) GetCallChannel(vfo string) (types.CallChannel, error) {
+	res, err := r.SendCommand("CC", vfo)
+	if err != nil {
+		return types.EmptyCallChannel, fmt.Errorf("unable to read call channel for vfo %s: %w", vfo, err)
+	}
+
+	c, err := types.ParseCallChannel(res)
+	if err != nil {
+		return types.EmptyCallChannel, fmt.Errorf("failed to parse call channel configuration: %w", err)
+	}
+	return c, nil
+}
+
+// SetCallChannel programs the call channel for the band given by
+// config.Band.
+func (r *Radio) SetCallChannel(config types.CallChannel) error {
+	_, err := r.SendCommand("CC", config.Serialize())
+	if err != nil {
+		return fmt.Errorf("failed to set call channel for vfo %d: %w", config.Band, err)
+	}
+
 	return nil
 }
 
@@ -392,6 +579,33 @@ func (r *Radio) GetPTTBand() (int, error) {
 	return pttBand, err
 }
 
+func (r *Radio) setPttAndControl(ctlBand, pttBand int) error {
+	_, err := r.SendCommand("BC", fmt.Sprintf("%d", ctlBand), fmt.Sprintf("%d", pttBand))
+	if err != nil {
+		return fmt.Errorf("failed to set ptt/control: %w", err)
+	}
+
+	return nil
+}
+
+// SetControlBand selects the control band without changing the PTT band.
+func (r *Radio) SetControlBand(band int) error {
+	_, pttBand, err := r.getPttAndControl()
+	if err != nil {
+		return err
+	}
+	return r.setPttAndControl(band, pttBand)
+}
+
+// SetPTTBand selects the PTT band without changing the control band.
+func (r *Radio) SetPTTBand(band int) error {
+	ctlBand, _, err := r.getPttAndControl()
+	if err != nil {
+		return err
+	}
+	return r.setPttAndControl(ctlBand, band)
+}
+
 // GetStatus returns information about the state of the radio, suitable for
 // use in a gui or web ui.
 func (r *Radio) GetStatus() (types.Status, error) {
@@ -447,6 +661,18 @@ func (r *Radio) GetStatus() (types.Status, error) {
 
 	status.BandMode = bandMode.String()
 
+	// Not every radio or firmware version answers PM, so don't let that
+	// prevent reporting everything else.
+	pm, err := r.GetPM()
+	switch {
+	case errors.Is(err, ErrUnavailableCommand) || errors.Is(err, ErrInvalidCommand):
+		status.PM = "unknown"
+	case err != nil:
+		return types.Status{}, fmt.Errorf("failed to get programmable memory: %w", err)
+	default:
+		status.PM = pm.String()
+	}
+
 	return status, nil
 }
 
@@ -482,3 +708,163 @@ func (r *Radio) SetBandMode(mode types.BandMode) error {
 
 	return nil
 }
+
+// GetPM returns the active programmable memory slot.
+func (r *Radio) GetPM() (types.PM, error) {
+	res, err := r.SendCommand("PM")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read programmable memory: %w", err)
+	}
+
+	pm, err := strconv.Atoi(res)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse programmable memory response: %w", err)
+	}
+
+	return types.PM(pm), nil
+}
+
+// SetPM recalls a programmable memory slot, replacing the current radio
+// configuration. Selecting types.PM_OFF leaves programmable memory mode.
+func (r *Radio) SetPM(pm types.PM) error {
+	_, err := r.SendCommand("PM", fmt.Sprintf("%d", pm))
+	if err != nil {
+		return fmt.Errorf("failed to set programmable memory: %w", err)
+	}
+
+	return nil
+}
+
+// GetMenu returns the radio menu settings.
+func (r *Radio) GetMenu() (types.Menu, error) {
+	res, err := r.SendCommand("MU")
+	if err != nil {
+		return types.EmptyMenu, fmt.Errorf("failed to read menu: %w", err)
+	}
+
+	menu, err := types.ParseMenu(res)
+	if err != nil {
+		return types.EmptyMenu, fmt.Errorf("failed to parse menu: %w", err)
+	}
+
+	return menu, nil
+}
+
+// SetMenu writes all of the radio menu settings.
+func (r *Radio) SetMenu(menu types.Menu) error {
+	_, err := r.SendCommand("MU", menu.Serialize())
+	if err != nil {
+		return fmt.Errorf("failed to set menu: %w", err)
+	}
+
+	return nil
+}
+
+// getSwitch reads a command that reports an on/off state as 0 or 1.
+func (r *Radio) getSwitch(cmd, description string) (bool, error) {
+	res, err := r.SendCommand(cmd)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", description, err)
+	}
+
+	val, err := strconv.Atoi(res)
+	if err != nil {
+		return false, fmt.Errorf("unable to parse %s response: %w", description, err)
+	}
+
+	return val != 0, nil
+}
+
+// setSwitch sets a command that accepts an on/off state as 0 or 1.
+func (r *Radio) setSwitch(cmd, description string, on bool) error {
+	val := "0"
+	if on {
+		val = "1"
+	}
+
+	if _, err := r.SendCommand(cmd, val); err != nil {
+		return fmt.Errorf("failed to set %s: %w", description, err)
+	}
+
+	return nil
+}
+
+// GetPower returns true if the radio is powered on.
+func (r *Radio) GetPower() (bool, error) {
+	return r.getSwitch("PS", "power state")
+}
+
+// SetPower turns the radio on or off.
+func (r *Radio) SetPower(on bool) error {
+	return r.setSwitch("PS", "power state", on)
+}
+
+// GetLock returns true if the front panel keys are locked.
+func (r *Radio) GetLock() (bool, error) {
+	return r.getSwitch("LK", "key lock")
+}
+
+// SetLock locks or unlocks the front panel keys.
+func (r *Radio) SetLock(locked bool) error {
+	return r.setSwitch("LK", "key lock", locked)
+}
+
+// Transmit keys the transmitter on the PTT band.
+func (r *Radio) Transmit() error {
+	if _, err := r.SendCommand("TX"); err != nil {
+		return fmt.Errorf("failed to start transmitting: %w", err)
+	}
+
+	return nil
+}
+
+// Receive unkeys the transmitter.
+func (r *Radio) Receive() error {
+	if _, err := r.SendCommand("RX"); err != nil {
+		return fmt.Errorf("failed to stop transmitting: %w", err)
+	}
+
+	return nil
+}
+
+// GetTxFrequency returns the frequency (in Hz) on which the given band
+// would transmit in its current operating mode.
+func (r *Radio) GetTxFrequency(vfo string) (int, error) {
+	settings, err := r.GetTxSettings(vfo)
+	if err != nil {
+		return 0, err
+	}
+	return types.TxFrequency(settings), nil
+}
+
+// GetTxSettings returns the vfo, memory channel or call channel that the
+// given band would transmit with in its current operating mode.
+func (r *Radio) GetTxSettings(vfo string) (types.RadioSettable, error) {
+	mode, err := r.GetVFOMode(vfo)
+	if err != nil {
+		return nil, err
+	}
+
+	switch mode {
+	case types.VFO_MODE_VFO:
+		v, err := r.GetVFO(vfo)
+		if err != nil {
+			return nil, err
+		}
+		return &v, nil
+	case types.VFO_MODE_MEMORY:
+		channel, err := r.GetCurrentChannel(vfo)
+		if err != nil {
+			return nil, err
+		}
+		return &channel, nil
+	case types.VFO_MODE_CALL:
+		call, err := r.GetCallChannel(vfo)
+		if err != nil {
+			return nil, err
+		}
+		return &call, nil
+	default:
+		return nil, fmt.Errorf("cannot transmit in %s mode", mode)
+	}
+}
@@ -0,0 +1,67 @@
+package radio
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrReadOnly is returned by SendCommand when a command that would change
+// the radio state is sent to a radio in read-only mode.
+var ErrReadOnly = errors.New("radio is in read-only mode")
+
+// readArgs maps each known command to the number of arguments it takes
+// when it only reads from the radio; sending the command with more
+// arguments changes the radio state. Commands that always change the radio
+// state map to alwaysWrites.
+var readArgs = map[string]int{
+	"AE": 0,
+	"BC": 0,
+	"BY": 1,
+	"CC": 1,
+	"DL": 0,
+	"DW": alwaysWrites,
+	"FO": 1,
+	"FV": 1,
+	"ID": 0,
+	"LK": 0,
+	"ME": 1,
+	"MN": 1,
+	"MR": 1,
+	"MU": 0,
+	"PC": 1,
+	"PM": 0,
+	"PS": 0,
+	"RX": alwaysWrites,
+	"SQ": 1,
+	"TX": alwaysWrites,
+	"TY": 0,
+	"UP": alwaysWrites,
+	"VM": 1,
+}
+
+const alwaysWrites = -1
+
+// IsWriteCommand returns true if sending cmd with args may change the
+// radio state. Arguments are counted after joining them with commas, as
+// SendCommand does. Commands that kwctl does not know about are assumed to
+// be writes.
+func IsWriteCommand(cmd string, args ...string) bool {
+	n, known := readArgs[strings.ToUpper(cmd)]
+	if !known || n == alwaysWrites {
+		return true
+	}
+
+	count := 0
+	if len(args) > 0 {
+		count = len(strings.Split(strings.Join(args, ","), ","))
+	}
+
+	return count > n
+}
+
+// WithReadOnly configures the radio to refuse any command that would
+// change its state.
+func (r *Radio) WithReadOnly(readOnly bool) *Radio {
+	r.readOnly = readOnly
+	return r
+}
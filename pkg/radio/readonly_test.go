@@ -0,0 +1,53 @@
+package radio
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsWriteCommand(t *testing.T) {
+	tests := []struct {
+		cmd      string
+		args     []string
+		expected bool
+	}{
+		{"ID", nil, false},
+		{"ME", []string{"001"}, false},
+		{"ME", []string{"001", "C"}, true},
+		{"ME", []string{"001,0146520000,0,0,0,0,0,0,08,08,000,00600000,0,0000000000,0,0"}, true},
+		{"MN", []string{"001"}, false},
+		{"MN", []string{"001", "CALL"}, true},
+		{"FO", []string{"0"}, false},
+		{"FO", []string{"0,0146520000,0,0,0,0,0,0,08,08,000,00600000,0"}, true},
+		{"MR", []string{"0"}, false},
+		{"MR", []string{"0", "001"}, true},
+		{"VM", []string{"0", "1"}, true},
+		{"DL", nil, false},
+		{"DL", []string{"1"}, true},
+		{"BC", []string{"0", "0"}, true},
+		{"UP", nil, true},
+		{"DW", nil, true},
+		{"TX", nil, true},
+		{"RX", nil, true},
+		{"XX", nil, true},
+	}
+
+	for _, tt := range tests {
+		if have := IsWriteCommand(tt.cmd, tt.args...); have != tt.expected {
+			t.Errorf("IsWriteCommand(%s, %v) = %t, expected %t", tt.cmd, tt.args, have, tt.expected)
+		}
+	}
+}
+
+func TestReadOnlyRejectsWrites(t *testing.T) {
+	// The port is never opened: a rejected command must fail before any
+	// attempt to talk to the radio.
+	r := NewRadio("/dev/null", 9600).WithReadOnly(true)
+
+	if _, err := r.SendCommand("ME", "001", "C"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly, got %v", err)
+	}
+	if err := r.SetBandMode(1); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly, got %v", err)
+	}
+}
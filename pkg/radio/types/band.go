@@ -0,0 +1,118 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// Band is a named range of frequencies in Hz. Both edges are inclusive.
+	Band struct {
+		Name    string
+		Lower   int
+		Upper   int
+		Amateur bool
+	}
+)
+
+// Bands lists the frequency bands known to kwctl. The amateur band edges
+// follow the US (ITU region 2) allocations.
+var Bands = []Band{
+	{Name: "2m", Lower: 144_000_000, Upper: 148_000_000, Amateur: true},
+	{Name: "1.25m", Lower: 222_000_000, Upper: 225_000_000, Amateur: true},
+	{Name: "70cm", Lower: 420_000_000, Upper: 450_000_000, Amateur: true},
+	{Name: "23cm", Lower: 1_240_000_000, Upper: 1_300_000_000, Amateur: true},
+	{Name: "air", Lower: 118_000_000, Upper: 137_000_000},
+	{Name: "vhf", Lower: 30_000_000, Upper: 300_000_000},
+	{Name: "uhf", Lower: 300_000_000, Upper: 3_000_000_000},
+}
+
+// Contains returns true if the frequency (in Hz) is inside the band.
+func (b Band) Contains(hz int) bool {
+	return hz >= b.Lower && hz <= b.Upper
+}
+
+// ContainsSignal returns true if a signal of the given bandwidth (in Hz)
+// centered on the frequency (in Hz) lies entirely inside the band. The
+// upper edge is exclusive, so a signal is never centered on it.
+func (b Band) ContainsSignal(hz int, bandwidth int) bool {
+	return hz-bandwidth/2 >= b.Lower && hz+bandwidth/2 <= b.Upper && hz < b.Upper
+}
+
+// Clamp returns the frequency (in Hz) limited to the edges of the band.
+func (b Band) Clamp(hz int) int {
+	return max(b.Lower, min(hz, b.Upper))
+}
+
+// ClampStep limits the frequency to the edges of the band, keeping it on
+// the grid of the given step code.
+func (b Band) ClampStep(f Frequency, step int) Frequency {
+	if b.Contains(int(f)) {
+		return f
+	}
+
+	clamped := Frequency(b.Clamp(int(f))).Snap(step)
+	switch {
+	case int(clamped) > b.Upper:
+		clamped = clamped.AddSteps(-1, step)
+	case int(clamped) < b.Lower:
+		clamped = clamped.AddSteps(1, step)
+	}
+	return clamped
+}
+
+// BandOf returns the first band in Bands containing the frequency (in Hz).
+// Since the amateur bands are listed first, this is the most specific band.
+func BandOf(hz int) (Band, bool) {
+	for _, band := range Bands {
+		if band.Contains(hz) {
+			return band, true
+		}
+	}
+	return Band{}, false
+}
+
+// AmateurBand returns the amateur band containing the frequency (in Hz).
+func AmateurBand(hz int) (Band, bool) {
+	for _, band := range Bands {
+		if band.Amateur && band.Contains(hz) {
+			return band, true
+		}
+	}
+	return Band{}, false
+}
+
+// AmateurTxBand returns the amateur band in which a signal of the given
+// bandwidth (in Hz) centered on the frequency (in Hz) may be transmitted.
+func AmateurTxBand(hz int, bandwidth int) (Band, bool) {
+	for _, band := range Bands {
+		if band.Amateur && band.ContainsSignal(hz, bandwidth) {
+			return band, true
+		}
+	}
+	return Band{}, false
+}
+
+func (b Band) String() string {
+	return b.Name
+}
+
+// ParseBand looks up a band by name (ignoring case).
+func ParseBand(s string) (Band, error) {
+	for _, band := range Bands {
+		if strings.EqualFold(band.Name, s) {
+			return band, nil
+		}
+	}
+
+	return Band{}, fmt.Errorf("invalid band: %s", s)
+}
+
+// BandNames returns the names of all known bands.
+func BandNames() []string {
+	var names []string
+	for _, band := range Bands {
+		names = append(names, band.Name)
+	}
+	return names
+}
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestBandOf(t *testing.T) {
+	tests := []struct {
+		hz       int
+		expected string
+		found    bool
+	}{
+		{146520000, "2m", true},
+		{127000000, "air", true},
+		{162550000, "vhf", true},
+		{446000000, "70cm", true},
+		{10000000, "", false},
+	}
+
+	for _, tt := range tests {
+		band, found := BandOf(tt.hz)
+		if found != tt.found || band.Name != tt.expected {
+			t.Errorf("BandOf(%d) = %s, %v; expected %s, %v", tt.hz, band.Name, found, tt.expected, tt.found)
+		}
+	}
+}
+
+func TestBandClampStep(t *testing.T) {
+	band, _ := ParseBand("2m")
+
+	tests := []struct {
+		name     string
+		freq     Frequency
+		step     int
+		expected Frequency
+	}{
+		{"inside", 146520000, 0, 146520000},
+		{"above", 148100000, 0, 148000000},
+		{"below", 143900000, 0, 144000000},
+		{"above, off grid", 148100000, 5, 147990000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := band.ClampStep(tt.freq, tt.step); got != tt.expected {
+				t.Errorf("ClampStep() = %d, expected %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAmateurTxBand(t *testing.T) {
+	tests := []struct {
+		name     string
+		hz       int
+		mode     int
+		expected string
+		found    bool
+	}{
+		{"inside", 146520000, 0, "2m", true},
+		{"upper edge", 148000000, 0, "", false},
+		{"upper edge, fm fits", 147992000, 0, "2m", true},
+		{"upper edge, fm too wide", 147995000, 0, "", false},
+		{"upper edge, nfm fits", 147994000, 1, "2m", true},
+		{"upper edge, am fits", 147997000, 2, "2m", true},
+		{"lower edge", 144000000, 0, "", false},
+		{"lower edge, fm fits", 144008000, 0, "2m", true},
+		{"70cm upper edge", 450000000, 0, "", false},
+		{"70cm upper edge, fm fits", 449990000, 0, "70cm", true},
+		{"70cm lower edge", 420000000, 0, "", false},
+		{"not amateur", 162550000, 0, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			band, found := AmateurTxBand(tt.hz, ModeBandwidth(tt.mode))
+			if found != tt.found || band.Name != tt.expected {
+				t.Errorf("AmateurTxBand(%d) = %s, %v; expected %s, %v", tt.hz, band.Name, found, tt.expected, tt.found)
+			}
+		})
+	}
+}
@@ -46,3 +46,13 @@ func (b *Bool) Set(value string) error {
 func (b *Bool) Type() string {
 	return "bool"
 }
+
+// MarshalText implements encoding.TextMarshaler.
+func (b *Bool) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *Bool) UnmarshalText(text []byte) error {
+	return b.Set(string(text))
+}
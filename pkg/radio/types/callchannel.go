@@ -0,0 +1,174 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+1 	Band
+2 	RX frequency in Hz 10 digit
+3 	RX step size
+4 	Shift direction
+5 	Reverse
+6 	Tone status
+7 	CTCSS status
+8 	DCS status
+9 	Tone frequency
+10 	CTCSS frequency
+11 	DCS frequency
+12 	Offset frequency in Hz 8 digit
+13 	Mode
+14 	TX frequency in Hz 10 digit, or transmit freq for odd split
+15 	TX step size
+*/
+
+type (
+	CallChannel struct {
+		Band      int
+		RxFreq    int
+		RxStep    int
+		Shift     int
+		Reverse   int
+		Tone      int
+		CTCSS     int
+		DCS       int
+		ToneFreq  int
+		CTCSSFreq int
+		DCSCode   int
+		Offset    int
+		Mode      int
+		TxFreq    int
+		TxStep    int
+	}
+
+	// DisplayCallChannel names the columns produced by
+	// CallChannel.Values.
+	DisplayCallChannel struct {
+		Band      string
+		RxFreq    string
+		RxStep    string
+		Shift     string
+		Reverse   string
+		ToneMode  string
+		ToneFreq  string
+		CTCSSFreq string
+		DCSCode   string
+		Offset    string
+		Mode      string
+		TxFreq    string
+		TxStep    string
+	}
+)
+
+var EmptyCallChannel = CallChannel{}
+
+// CC 0,0146520000,0,0,0,0,0,0,08,08,000,00600000,0,0000000000,0
+func ParseCallChannel(s string) (CallChannel, error) {
+	parts := []int{}
+	for sval := range strings.SplitSeq(s, ",") {
+		ival, err := strconv.Atoi(sval)
+		if err != nil {
+			return EmptyCallChannel, err
+		}
+		parts = append(parts, ival)
+	}
+
+	if len(parts) != 15 {
+		return EmptyCallChannel, fmt.Errorf("invalid call channel specification")
+	}
+	return CallChannel{
+		Band:      parts[0],
+		RxFreq:    parts[1],
+		RxStep:    parts[2],
+		Shift:     parts[3],
+		Reverse:   parts[4],
+		Tone:      parts[5],
+		CTCSS:     parts[6],
+		DCS:       parts[7],
+		ToneFreq:  parts[8],
+		CTCSSFreq: parts[9],
+		DCSCode:   parts[10],
+		Offset:    parts[11],
+		Mode:      parts[12],
+		TxFreq:    parts[13],
+		TxStep:    parts[14],
+	}, nil
+}
+
+// Produce format expected by radio commands
+func (c CallChannel) Serialize() string {
+	return fmt.Sprintf("%d,%010d,%d,%d,%d,%d,%d,%d,%02d,%02d,%03d,%08d,%d,%010d,%d",
+		c.Band,
+		c.RxFreq,
+		c.RxStep,
+		c.Shift,
+		c.Reverse,
+		c.Tone,
+		c.CTCSS,
+		c.DCS,
+		c.ToneFreq,
+		c.CTCSSFreq,
+		c.DCSCode,
+		c.Offset,
+		c.Mode,
+		c.TxFreq,
+		c.TxStep,
+	)
+}
+
+// Produce a row suitable for table formatting, with the same columns as
+// DisplayCallChannel
+func (c CallChannel) Values() []string {
+	return []string{
+		fmt.Sprintf("%d", c.Band),
+		NewFrequencyMHz(&c.RxFreq).String(),
+		NewStepSize(&c.RxStep).String(),
+		NewShift(&c.Shift).String(),
+		NewBool(&c.Reverse).String(),
+		GetToneMode(&c).String(),
+		NewTone(&c.ToneFreq).String(),
+		NewTone(&c.CTCSSFreq).String(),
+		NewDCS(&c.DCSCode).String(),
+		NewFrequencyMHz(&c.Offset).String(),
+		NewMode(&c.Mode).String(),
+		NewFrequencyMHz(&c.TxFreq).String(),
+		NewStepSize(&c.TxStep).String(),
+	}
+}
+
+// Produce human friendly output
+func (c CallChannel) String() string {
+	return strings.Join(c.Values(), ",")
+}
+
+// RadioSettable interface implementation
+
+func (c *CallChannel) GetRxFreq() int    { return c.RxFreq }
+func (c *CallChannel) SetRxFreq(v int)   { c.RxFreq = v }
+func (c *CallChannel) GetRxStep() int    { return c.RxStep }
+func (c *CallChannel) SetRxStep(v int)   { c.RxStep = v }
+func (c *CallChannel) GetMode() int      { return c.Mode }
+func (c *CallChannel) SetMode(v int)     { c.Mode = v }
+func (c *CallChannel) GetShift() int     { return c.Shift }
+func (c *CallChannel) SetShift(v int)    { c.Shift = v }
+func (c *CallChannel) GetReverse() int   { return c.Reverse }
+func (c *CallChannel) SetReverse(v int)  { c.Reverse = v }
+func (c *CallChannel) GetOffset() int    { return c.Offset }
+func (c *CallChannel) SetOffset(v int)   { c.Offset = v }
+func (c *CallChannel) GetTone() int      { return c.Tone }
+func (c *CallChannel) SetTone(v int)     { c.Tone = v }
+func (c *CallChannel) GetCTCSS() int     { return c.CTCSS }
+func (c *CallChannel) SetCTCSS(v int)    { c.CTCSS = v }
+func (c *CallChannel) GetDCS() int       { return c.DCS }
+func (c *CallChannel) SetDCS(v int)      { c.DCS = v }
+func (c *CallChannel) GetToneFreq() int  { return c.ToneFreq }
+func (c *CallChannel) SetToneFreq(v int) { c.ToneFreq = v }
+func (c *CallChannel) GetCTCSSFreq() int { return c.CTCSSFreq }
+func (c *CallChannel) SetCTCSSFreq(v int) {
+	c.CTCSSFreq = v
+}
+func (c *CallChannel) GetDCSCode() int  { return c.DCSCode }
+func (c *CallChannel) SetDCSCode(v int) { c.DCSCode = v }
+func (c *CallChannel) GetTxFreq() int   { return c.TxFreq }
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestParseCallChannel(t *testing.T) {
+	raw := "0,0146520000,0,2,0,1,0,0,08,08,000,00600000,0,0000000000,0"
+	expected := CallChannel{Band: 0, RxFreq: 146520000, Shift: 2, Tone: 1, ToneFreq: 8, CTCSSFreq: 8, Offset: 600000}
+
+	have, err := ParseCallChannel(raw)
+	if err != nil {
+		t.Fatalf("expected success, failed with: %v", err)
+	}
+	if have != expected {
+		t.Errorf("unexpected result: %+v", have)
+	}
+
+	if serialized := have.Serialize(); serialized != raw {
+		t.Errorf("have %s, expected %s", serialized, raw)
+	}
+
+	if _, err := ParseCallChannel("0,0146520000,0"); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestCallChannelImplementsRadioSettable(t *testing.T) {
+	var _ RadioSettable = &CallChannel{}
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 )
@@ -45,6 +46,35 @@ type (
 		TxStep    int
 		Lockout   int
 	}
+
+	// DisplayChannel is a Channel in human friendly form, as shown by
+	// Values. The Tone, CTCSS and DCS fields are combined into ToneMode.
+	DisplayChannel struct {
+		Name      string
+		Number    string
+		RxFreq    string
+		RxStep    string
+		Shift     string
+		Reverse   string
+		ToneMode  string
+		ToneFreq  string
+		CTCSSFreq string
+		DCSCode   string
+		Offset    string
+		Mode      string
+		TxFreq    string
+		TxStep    string
+		Lockout   string
+	}
+
+	// FieldDiff describes a field whose value differs between two
+	// channels. Values are in the same human friendly format as
+	// Channel.Values.
+	FieldDiff struct {
+		Field string
+		Old   string
+		New   string
+	}
 )
 
 var EmptyChannel = Channel{}
@@ -83,49 +113,109 @@ func ParseChannel(s string) (Channel, error) {
 	}, nil
 }
 
-// Produce a row suitable for table formatting
+func (c Channel) Display() DisplayChannel {
+	return DisplayChannel{
+		Name:      c.Name,
+		Number:    fmt.Sprintf("%03d", c.Number),
+		RxFreq:    NewFrequencyMHz(&c.RxFreq).String(),
+		RxStep:    NewStepSize(&c.RxStep).String(),
+		Shift:     NewShift(&c.Shift).String(),
+		Reverse:   NewBool(&c.Reverse).String(),
+		ToneMode:  GetToneMode(&c).String(),
+		ToneFreq:  NewTone(&c.ToneFreq).String(),
+		CTCSSFreq: NewTone(&c.CTCSSFreq).String(),
+		DCSCode:   NewDCS(&c.DCSCode).String(),
+		Offset:    NewFrequencyMHz(&c.Offset).String(),
+		Mode:      NewMode(&c.Mode).String(),
+		TxFreq:    NewFrequencyMHz(&c.TxFreq).String(),
+		TxStep:    NewStepSize(&c.TxStep).String(),
+		Lockout:   NewBool(&c.Lockout).String(),
+	}
+}
+
+// Produce a row suitable for table formatting, with the same columns as
+// DisplayChannel
 func (c Channel) Values() []string {
+	d := c.Display()
 	return []string{
-		c.Name,
-		fmt.Sprintf("%03d", c.Number),
-		NewFrequencyMHz(&c.RxFreq).String(),
-		NewStepSize(&c.RxStep).String(),
-		NewShift(&c.Shift).String(),
-		NewBool(&c.Reverse).String(),
-		NewBool(&c.Tone).String(),
-		NewBool(&c.CTCSS).String(),
-		NewBool(&c.DCS).String(),
-		NewTone(&c.ToneFreq).String(),
-		NewTone(&c.CTCSSFreq).String(),
-		NewDCS(&c.DCSCode).String(),
-		NewFrequencyMHz(&c.Offset).String(),
-		NewMode(&c.Mode).String(),
-		NewFrequencyMHz(&c.TxFreq).String(),
-		NewStepSize(&c.TxStep).String(),
-		NewBool(&c.Lockout).String(),
+		d.Name,
+		d.Number,
+		d.RxFreq,
+		d.RxStep,
+		d.Shift,
+		d.Reverse,
+		d.ToneMode,
+		d.ToneFreq,
+		d.CTCSSFreq,
+		d.DCSCode,
+		d.Offset,
+		d.Mode,
+		d.TxFreq,
+		d.TxStep,
+		d.Lockout,
 	}
 }
 
 // Produce human friendly output
 func (c Channel) String() string {
-	return fmt.Sprintf("[%-6s] ", c.Name) + strings.Join([]string{
-		fmt.Sprintf("%03d", c.Number),
-		NewFrequencyMHz(&c.RxFreq).String(),
-		NewStepSize(&c.RxStep).String(),
-		NewShift(&c.Shift).String(),
-		NewBool(&c.Reverse).String(),
-		NewBool(&c.Tone).String(),
-		NewBool(&c.CTCSS).String(),
-		NewBool(&c.DCS).String(),
-		NewTone(&c.ToneFreq).String(),
-		NewTone(&c.CTCSSFreq).String(),
-		NewDCS(&c.DCSCode).String(),
-		NewFrequencyMHz(&c.Offset).String(),
-		NewMode(&c.Mode).String(),
-		NewFrequencyMHz(&c.TxFreq).String(),
-		NewStepSize(&c.TxStep).String(),
-		NewBool(&c.Lockout).String(),
-	}, ",")
+	values := c.Values()
+	return fmt.Sprintf("[%-6s] ", c.Name) + strings.Join(values[1:], ",")
+}
+
+// ToVFO converts c into the configuration for the given VFO. A channel
+// with an odd split is converted to a shift and offset, which fails if the
+// split cannot be represented (see VFO.SetSplit).
+func (c Channel) ToVFO(vfo int) (VFO, error) {
+	v := VFO{
+		VFO:       vfo,
+		RxFreq:    c.RxFreq,
+		RxStep:    c.RxStep,
+		Shift:     c.Shift,
+		Reverse:   c.Reverse,
+		Tone:      c.Tone,
+		CTCSS:     c.CTCSS,
+		DCS:       c.DCS,
+		ToneFreq:  c.ToneFreq,
+		CTCSSFreq: c.CTCSSFreq,
+		DCSCode:   c.DCSCode,
+		Offset:    c.Offset,
+		Mode:      c.Mode,
+	}
+
+	if c.TxFreq != 0 {
+		if err := v.SetSplit(c.TxFreq); err != nil {
+			return EmptyVFO, fmt.Errorf("channel %03d: %w", c.Number, err)
+		}
+	}
+
+	return v, nil
+}
+
+// Diff returns the fields that differ between c and other, in field order.
+func (c Channel) Diff(other Channel) []FieldDiff {
+	return diffValues(reflect.TypeOf(DisplayChannel{}), c.Values(), other.Values())
+}
+
+// diffValues compares two rows of values produced by a Values method
+// whose columns are the fields of the struct type t.
+func diffValues(t reflect.Type, oldValues, newValues []string) []FieldDiff {
+	var diffs []FieldDiff
+
+	for i := range oldValues {
+		if oldValues[i] != newValues[i] {
+			diffs = append(diffs, FieldDiff{
+				Field: t.Field(i).Name,
+				Old:   oldValues[i],
+				New:   newValues[i],
+			})
+		}
+	}
+
+	return diffs
+}
+
+func (d FieldDiff) String() string {
+	return fmt.Sprintf("%s: %s -> %s", d.Field, d.Old, d.New)
 }
 
 // Produce format expected by radio commands
@@ -178,3 +268,4 @@ func (c *Channel) SetCTCSSFreq(v int) {
 }
 func (c *Channel) GetDCSCode() int  { return c.DCSCode }
 func (c *Channel) SetDCSCode(v int) { c.DCSCode = v }
+func (c *Channel) GetTxFreq() int   { return c.TxFreq }
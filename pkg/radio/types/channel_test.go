@@ -1,7 +1,10 @@
 package types
 
 import (
+	"encoding/json"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 type (
@@ -48,15 +51,35 @@ func TestParseChannel(t *testing.T) {
 func TestStringifyChannel(t *testing.T) {
 	inputs := []ChannelTestItem{
 		{
-			"[      ] 101,145.09,0,simplex,false,false,false,false,0.0,0.0,023,0.000,FM,0.00,0,true",
+			"[      ] 101,145.090000,5,simplex,false,none,88.5,88.5,023,0.000000,FM,0.000000,5,true",
 			Channel{Number: 101, RxFreq: 145090000, RxStep: 0, Shift: 0, Reverse: 0, Tone: 0, CTCSS: 0, DCS: 0, ToneFreq: 8, CTCSSFreq: 8, DCSCode: 0, Offset: 0, Mode: 0, TxFreq: 0, TxStep: 0, Lockout: 1},
 			true,
 		},
 		{
-			"[      ] 001,145.09,0,simplex,false,false,false,false,0.0,0.0,023,0.000,FM,0.00,0,false",
+			"[      ] 001,145.090000,5,simplex,false,none,88.5,88.5,023,0.000000,FM,0.000000,5,false",
 			Channel{Number: 1, RxFreq: 145090000, RxStep: 0, Shift: 0, Reverse: 0, Tone: 0, CTCSS: 0, DCS: 0, ToneFreq: 8, CTCSSFreq: 8, DCSCode: 0, Offset: 0, Mode: 0, TxFreq: 0, TxStep: 0, Lockout: 0},
 			true,
 		},
+		{
+			"[RPT   ] 002,146.820000,5,down,false,tone,146.2,88.5,023,0.600000,FM,0.000000,5,false",
+			Channel{Number: 2, Name: "RPT", RxFreq: 146820000, Shift: 2, Tone: 1, ToneFreq: 23, CTCSSFreq: 8, Offset: 600000},
+			true,
+		},
+		{
+			"[RPT   ] 003,146.820000,5,down,false,tsql,146.2,146.2,023,0.600000,FM,0.000000,5,false",
+			Channel{Number: 3, Name: "RPT", RxFreq: 146820000, Shift: 2, Tone: 1, CTCSS: 1, ToneFreq: 23, CTCSSFreq: 23, Offset: 600000},
+			true,
+		},
+		{
+			"[RPT   ] 004,146.820000,5,down,false,dcs,88.5,88.5,023,0.600000,FM,0.000000,5,false",
+			Channel{Number: 4, Name: "RPT", RxFreq: 146820000, Shift: 2, DCS: 1, ToneFreq: 8, CTCSSFreq: 8, Offset: 600000},
+			true,
+		},
+		{
+			"[RPT   ] 005,146.820000,5,down,false,<invalid>,88.5,88.5,023,0.600000,FM,0.000000,5,false",
+			Channel{Number: 5, Name: "RPT", RxFreq: 146820000, Shift: 2, CTCSS: 1, DCS: 1, ToneFreq: 8, CTCSSFreq: 8, Offset: 600000},
+			true,
+		},
 	}
 
 	for _, input := range inputs {
@@ -66,3 +89,97 @@ func TestStringifyChannel(t *testing.T) {
 		}
 	}
 }
+
+func TestChannelDiff(t *testing.T) {
+	old := Channel{Number: 1, RxFreq: 146820000, Shift: 2, Tone: 1, ToneFreq: 8, CTCSSFreq: 8, Offset: 600000}
+	new := old
+	new.ToneFreq = 12
+	new.Lockout = 1
+
+	expected := []FieldDiff{
+		{Field: "ToneFreq", Old: "88.5", New: "100.0"},
+		{Field: "Lockout", Old: "false", New: "true"},
+	}
+
+	have := old.Diff(new)
+	if len(have) != len(expected) {
+		t.Fatalf("expected %d differences, got %d: %v", len(expected), len(have), have)
+	}
+	for i := range expected {
+		if have[i] != expected[i] {
+			t.Errorf("difference %d: expected %v, got %v", i, expected[i], have[i])
+		}
+	}
+
+	if diffs := old.Diff(old); len(diffs) != 0 {
+		t.Errorf("expected no differences, got %v", diffs)
+	}
+}
+
+func TestChannelMarshalRoundTrip(t *testing.T) {
+	channel := Channel{
+		Name:      "BAKBAY",
+		Number:    90,
+		RxFreq:    146820000,
+		Shift:     2,
+		Tone:      1,
+		ToneFreq:  23,
+		CTCSSFreq: 8,
+		Offset:    600000,
+		TxStep:    1,
+		Lockout:   1,
+	}
+
+	data, err := json.Marshal(channel)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	var fromJSON Channel
+	if err := json.Unmarshal(data, &fromJSON); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	if fromJSON != channel {
+		t.Errorf("json round trip: have %+v, expected %+v", fromJSON, channel)
+	}
+
+	data, err = yaml.Marshal(channel)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() failed: %v", err)
+	}
+	var fromYAML Channel
+	if err := yaml.Unmarshal(data, &fromYAML); err != nil {
+		t.Fatalf("yaml.Unmarshal() failed: %v", err)
+	}
+	if fromYAML != channel {
+		t.Errorf("yaml round trip: have %+v, expected %+v", fromYAML, channel)
+	}
+
+	var invalid Channel
+	if err := yaml.Unmarshal([]byte("txStep: 7\n"), &invalid); err == nil {
+		t.Errorf("expected error decoding invalid step size")
+	}
+}
+
+func TestChannelMarshalInvalidToneMode(t *testing.T) {
+	// CTCSS together with DCS is not a tone mode
+	channel := Channel{Number: 5, RxFreq: 146820000, CTCSS: 1, DCS: 1, ToneFreq: 8, CTCSSFreq: 8}
+
+	data, err := json.Marshal(channel)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	var fromJSON Channel
+	if err := json.Unmarshal(data, &fromJSON); err != nil {
+		t.Fatalf("json.Unmarshal(%s) failed: %v", data, err)
+	}
+	if fromJSON != channel {
+		t.Errorf("json round trip: have %+v, expected %+v", fromJSON, channel)
+	}
+
+	if _, err := TONE_MODE_INVALID.MarshalText(); err == nil {
+		t.Errorf("expected error marshalling invalid tone mode")
+	}
+	if err := json.Unmarshal([]byte(`{"toneMode":"tone","toneFlags":{"tone":true}}`), &fromJSON); err == nil {
+		t.Errorf("expected error with both toneMode and toneFlags")
+	}
+}
@@ -0,0 +1,171 @@
+package types
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+)
+
+type (
+	// ChannelFilter selects memory channels by their settings. Nil fields
+	// are ignored. A filter that sets no fields matches every channel,
+	// including empty ones; otherwise only channels in use can match,
+	// unless Empty is set.
+	ChannelFilter struct {
+		MinFreq *int
+		MaxFreq *int
+		Band    *Band
+		Mode    *int
+		Shift   *int
+		Tone    *int
+		DCSCode *int
+		Lockout *bool
+		Name    *string
+
+		// Used and Empty select channels that are in use or empty.
+		Used  bool
+		Empty bool
+	}
+
+	// ChannelFilterValues holds temporary storage for channel filter flag
+	// values.
+	ChannelFilterValues struct {
+		Freq    string
+		Band    string
+		Mode    int
+		Shift   int
+		Tone    int
+		DCSCode int
+		Name    string
+	}
+)
+
+// MatchChannelName reports whether a channel name matches pattern. Matching
+// is case-insensitive and pattern may contain glob wildcards (see
+// path.Match).
+func MatchChannelName(pattern, name string) bool {
+	matched, _ := path.Match(strings.ToUpper(pattern), strings.ToUpper(name))
+	return matched
+}
+
+// hasAttributes returns true if the filter selects on any channel setting.
+func (f ChannelFilter) hasAttributes() bool {
+	return f.MinFreq != nil || f.MaxFreq != nil || f.Band != nil ||
+		f.Mode != nil || f.Shift != nil || f.Tone != nil ||
+		f.DCSCode != nil || f.Lockout != nil || f.Name != nil
+}
+
+// Match returns true if the channel is selected by the filter.
+func (f ChannelFilter) Match(c Channel) bool {
+	if c.RxFreq == 0 {
+		return f.Empty || (!f.Used && !f.hasAttributes())
+	}
+
+	if f.Empty && !f.Used {
+		return false
+	}
+
+	switch {
+	case f.MinFreq != nil && c.RxFreq < *f.MinFreq:
+		return false
+	case f.MaxFreq != nil && c.RxFreq > *f.MaxFreq:
+		return false
+	case f.Band != nil && !f.Band.Contains(c.RxFreq):
+		return false
+	case f.Mode != nil && c.Mode != *f.Mode:
+		return false
+	case f.Shift != nil && c.Shift != *f.Shift:
+		return false
+	case f.Tone != nil && !(c.Tone == 1 && c.ToneFreq == *f.Tone) && !(c.CTCSS == 1 && c.CTCSSFreq == *f.Tone):
+		return false
+	case f.DCSCode != nil && !(c.DCS == 1 && c.DCSCode == *f.DCSCode):
+		return false
+	case f.Lockout != nil && (c.Lockout == 1) != *f.Lockout:
+		return false
+	case f.Name != nil && !MatchChannelName(*f.Name, c.Name):
+		return false
+	}
+
+	return true
+}
+
+// AddChannelFilterFlags adds channel filter flags to the provided FlagSet.
+// Each flag name is prefixed with prefix, so that the filter flags can be
+// used alongside the radio setting flags (e.g. "match-mode" vs "mode").
+func AddChannelFilterFlags(flags *flag.FlagSet, values *ChannelFilterValues, prefix string) {
+	flags.StringVarP(&values.Freq, prefix+"freq", "", "", "match frequency or range in MHz (e.g., 146.52 or 144-148)")
+	flags.StringVarP(&values.Band, prefix+"band", "", "", fmt.Sprintf("match band (%s)", strings.Join(BandNames(), ", ")))
+	flags.VarP(NewMode(&values.Mode), prefix+"mode", "", "match mode (FM, NFM, AM)")
+	flags.VarP(NewShift(&values.Shift), prefix+"shift", "", "match shift (simplex, up, down)")
+	flags.VarP(NewTone(&values.Tone), prefix+"tone", "", "match channels using CTCSS tone when sending or receiving")
+	flags.VarP(NewDCS(&values.DCSCode), prefix+"dcs", "", "match channels using DCS code")
+	flags.Bool(prefix+"lockout", false, "match channels skipped during scan")
+	flags.Bool(prefix+"no-lockout", false, "match channels not skipped during scan")
+	flags.StringVarP(&values.Name, prefix+"name", "", "", "match channel name (may contain glob patterns)")
+}
+
+// ApplyChannelFilterFlags applies only the filter flags that were set
+// (visited) to the filter.
+func ApplyChannelFilterFlags(flags *flag.FlagSet, values *ChannelFilterValues, prefix string, filter *ChannelFilter) error {
+	var err error
+
+	flags.Visit(func(f *flag.Flag) {
+		if err != nil || !strings.HasPrefix(f.Name, prefix) {
+			return
+		}
+
+		switch strings.TrimPrefix(f.Name, prefix) {
+		case "freq":
+			err = parseFrequencyRange(values.Freq, filter)
+		case "band":
+			var band Band
+			band, err = ParseBand(values.Band)
+			filter.Band = &band
+		case "mode":
+			filter.Mode = &values.Mode
+		case "shift":
+			filter.Shift = &values.Shift
+		case "tone":
+			filter.Tone = &values.Tone
+		case "dcs":
+			filter.DCSCode = &values.DCSCode
+		case "lockout":
+			lockout := true
+			filter.Lockout = &lockout
+		case "no-lockout":
+			lockout := false
+			filter.Lockout = &lockout
+		case "name":
+			filter.Name = &values.Name
+		}
+	})
+
+	return err
+}
+
+// parseFrequencyRange parses a frequency ("146.52") or frequency range
+// ("144-148") in MHz.
+func parseFrequencyRange(s string, filter *ChannelFilter) error {
+	var minFreq, maxFreq int
+
+	lower, upper, isRange := strings.Cut(s, "-")
+	if err := NewFrequencyMHz(&minFreq).Set(strings.TrimSpace(lower)); err != nil {
+		return err
+	}
+	maxFreq = minFreq
+	if isRange {
+		if err := NewFrequencyMHz(&maxFreq).Set(strings.TrimSpace(upper)); err != nil {
+			return err
+		}
+	}
+
+	if minFreq > maxFreq {
+		return fmt.Errorf("invalid frequency range: %s", s)
+	}
+
+	filter.MinFreq = &minFreq
+	filter.MaxFreq = &maxFreq
+	return nil
+}
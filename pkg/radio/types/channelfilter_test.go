@@ -0,0 +1,103 @@
+package types
+
+import (
+	"testing"
+
+	flag "github.com/spf13/pflag"
+)
+
+var (
+	filterTestRepeater = Channel{Name: "BAKBAY", Number: 90, RxFreq: 146820000, Shift: 2, Tone: 1, ToneFreq: 23, CTCSSFreq: 8, Offset: 600000}
+	filterTestTsql     = Channel{Name: "MRAHOP", Number: 11, RxFreq: 447775000, Shift: 2, Tone: 1, CTCSS: 1, ToneFreq: 12, CTCSSFreq: 12, Offset: 5000000, Mode: 1}
+	filterTestDCS      = Channel{Name: "DCSRPT", Number: 12, RxFreq: 442100000, Shift: 1, DCS: 1, DCSCode: 14, ToneFreq: 12, Lockout: 1}
+	filterTestEmpty    = Channel{Number: 13}
+)
+
+func filterFromFlags(t *testing.T, args ...string) ChannelFilter {
+	t.Helper()
+	var values ChannelFilterValues
+	var filter ChannelFilter
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddChannelFilterFlags(flags, &values, "")
+	flags.BoolVar(&filter.Used, "used", false, "")
+	flags.BoolVar(&filter.Empty, "empty", false, "")
+	if err := flags.Parse(args); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	if err := ApplyChannelFilterFlags(flags, &values, "", &filter); err != nil {
+		t.Fatalf("failed to apply flags: %v", err)
+	}
+	return filter
+}
+
+func TestChannelFilter_Match(t *testing.T) {
+	channels := []Channel{filterTestRepeater, filterTestTsql, filterTestDCS, filterTestEmpty}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected []int
+	}{
+		{"no filter", nil, []int{90, 11, 12, 13}},
+		{"used", []string{"--used"}, []int{90, 11, 12}},
+		{"empty", []string{"--empty"}, []int{13}},
+		{"used and empty", []string{"--used", "--empty"}, []int{90, 11, 12, 13}},
+		{"frequency range", []string{"--freq", "440-450"}, []int{11, 12}},
+		{"single frequency", []string{"--freq", "146.82"}, []int{90}},
+		{"band", []string{"--band", "2M"}, []int{90}},
+		{"mode", []string{"--mode", "NFM"}, []int{11}},
+		{"shift", []string{"--shift", "down"}, []int{90, 11}},
+		{"tone matches tx tone", []string{"--tone", "146.2"}, []int{90}},
+		{"tone matches ctcss", []string{"--tone", "100.0"}, []int{11}},
+		{"tone ignores disabled tone", []string{"--tone", "67.0"}, nil},
+		{"dcs", []string{"--dcs", "073"}, []int{12}},
+		{"lockout", []string{"--lockout"}, []int{12}},
+		{"no lockout", []string{"--no-lockout"}, []int{90, 11}},
+		{"name pattern", []string{"--name", "mr*"}, []int{11}},
+		{"combined filters", []string{"--shift", "down", "--band", "70cm"}, []int{11}},
+		{"filter with empty", []string{"--empty", "--band", "2m"}, []int{13}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := filterFromFlags(t, tt.args...)
+
+			var matched []int
+			for _, channel := range channels {
+				if filter.Match(channel) {
+					matched = append(matched, channel.Number)
+				}
+			}
+
+			if len(matched) != len(tt.expected) {
+				t.Fatalf("matched %v, expected %v", matched, tt.expected)
+			}
+			for i := range matched {
+				if matched[i] != tt.expected[i] {
+					t.Fatalf("matched %v, expected %v", matched, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyChannelFilterFlags_Invalid(t *testing.T) {
+	tests := [][]string{
+		{"--freq", "148-144"},
+		{"--freq", "abc"},
+		{"--band", "6m"},
+	}
+
+	for _, args := range tests {
+		var values ChannelFilterValues
+		var filter ChannelFilter
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		AddChannelFilterFlags(flags, &values, "")
+		if err := flags.Parse(args); err != nil {
+			t.Fatalf("failed to parse flags: %v", err)
+		}
+		if err := ApplyChannelFilterFlags(flags, &values, "", &filter); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+}
@@ -148,3 +148,13 @@ func (t *DCS) Set(value string) error {
 func (t *DCS) Type() string {
 	return "dcs"
 }
+
+// MarshalText implements encoding.TextMarshaler.
+func (t *DCS) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *DCS) UnmarshalText(text []byte) error {
+	return t.Set(string(text))
+}
@@ -3,14 +3,138 @@ package types
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
+// Frequency is a frequency in Hz.
+type Frequency int
+
 // FrequencyMHz is a pflag.Value implementation that handles frequency conversion
 // from MHz (human-readable format) to Hz (internal storage format).
 type FrequencyMHz struct {
 	valuePtr *int // Pointer to the Hz value being configured
 }
 
+// frequencyUnits maps unit suffixes (in lower case) to their size in Hz.
+// Longer suffixes must be checked first.
+var frequencyUnits = []struct {
+	suffix string
+	hz     int
+}{
+	{"mhz", 1_000_000},
+	{"khz", 1_000},
+	{"hz", 1},
+	{"m", 1_000_000},
+	{"k", 1_000},
+}
+
+// ParseFrequency parses a decimal frequency with an optional unit suffix
+// (Hz, kHz or MHz, or k or M; case is ignored). Values without a suffix are
+// in MHz. Parsing is exact: "146.52" is 146520000 Hz, and values with more
+// precision than 1 Hz are rejected.
+func ParseFrequency(s string) (Frequency, error) {
+	value := strings.TrimSpace(s)
+	unit := 1_000_000
+
+	lower := strings.ToLower(value)
+	for _, u := range frequencyUnits {
+		if strings.HasSuffix(lower, u.suffix) {
+			value = strings.TrimSpace(value[:len(value)-len(u.suffix)])
+			unit = u.hz
+			break
+		}
+	}
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" && fraction == "" {
+		return 0, fmt.Errorf("invalid frequency: %q", s)
+	}
+	if strings.HasPrefix(value, "-") {
+		return 0, fmt.Errorf("frequency cannot be negative")
+	}
+	if strings.Trim(whole, "0123456789") != "" || strings.Trim(fraction, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid frequency: %q", s)
+	}
+	if len(whole) > 10 {
+		return 0, fmt.Errorf("frequency out of range: %q", s)
+	}
+
+	hz := 0
+	if whole != "" {
+		n, err := strconv.Atoi(whole)
+		if err != nil {
+			return 0, fmt.Errorf("invalid frequency: %w", err)
+		}
+		hz = n * unit
+	}
+
+	// Each fractional digit must fall on a whole number of Hz.
+	place := unit
+	for i, digit := range fraction {
+		if place%10 != 0 {
+			if strings.Trim(fraction[i:], "0") != "" {
+				return 0, fmt.Errorf("invalid frequency: %q has more precision than 1 Hz", s)
+			}
+			break
+		}
+		place /= 10
+		hz += int(digit-'0') * place
+	}
+
+	return Frequency(hz), nil
+}
+
+// Format returns the frequency in MHz with the given number of decimal
+// places, rounding to the nearest value.
+func (f Frequency) Format(precision int) string {
+	precision = max(0, min(precision, 6))
+
+	scale := 1
+	for range 6 - precision {
+		scale *= 10
+	}
+
+	units := (int(f) + scale/2) / scale
+	if precision == 0 {
+		return fmt.Sprintf("%d", units)
+	}
+
+	divisor := 1_000_000 / scale
+	return fmt.Sprintf("%d.%0*d", units/divisor, precision, units%divisor)
+}
+
+// String returns the frequency in MHz with full (1 Hz) precision.
+func (f Frequency) String() string {
+	return f.Format(6)
+}
+
+// Compact returns the frequency in MHz with at least three decimal places,
+// and more only when they are needed to represent it exactly.
+func (f Frequency) Compact() string {
+	precision := 3
+	for ; precision < 6; precision++ {
+		if p, _ := ParseFrequency(f.Format(precision)); p == f {
+			break
+		}
+	}
+	return f.Format(precision)
+}
+
+// MarshalText implements encoding.TextMarshaler using the compact format.
+func (f Frequency) MarshalText() ([]byte, error) {
+	return []byte(f.Compact()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseFrequency.
+func (f *Frequency) UnmarshalText(text []byte) error {
+	val, err := ParseFrequency(string(text))
+	if err != nil {
+		return err
+	}
+	*f = val
+	return nil
+}
+
 // NewFrequencyMHz creates a new FrequencyMHz flag value that updates the provided Hz pointer
 func NewFrequencyMHz(hzPtr *int) *FrequencyMHz {
 	return &FrequencyMHz{valuePtr: hzPtr}
@@ -21,21 +145,16 @@ func (f *FrequencyMHz) String() string {
 	if f.valuePtr == nil {
 		return "0"
 	}
-	mhz := float64(*f.valuePtr) / 1_000_000
-	return fmt.Sprintf("%.6f", mhz)
+	return Frequency(*f.valuePtr).String()
 }
 
-// Set parses a MHz value and stores it as Hz
+// Set parses a frequency (in MHz unless a unit is given) and stores it as Hz
 func (f *FrequencyMHz) Set(value string) error {
-	mhz, err := strconv.ParseFloat(value, 64)
+	hz, err := ParseFrequency(value)
 	if err != nil {
-		return fmt.Errorf("invalid frequency: %w", err)
+		return err
 	}
-	if mhz < 0 {
-		return fmt.Errorf("frequency cannot be negative")
-	}
-	hz := int(mhz * 1_000_000)
-	*f.valuePtr = hz
+	*f.valuePtr = int(hz)
 	return nil
 }
 
@@ -43,3 +162,13 @@ func (f *FrequencyMHz) Set(value string) error {
 func (f *FrequencyMHz) Type() string {
 	return "frequencyMHz"
 }
+
+// MarshalText implements encoding.TextMarshaler.
+func (f *FrequencyMHz) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *FrequencyMHz) UnmarshalText(text []byte) error {
+	return f.Set(string(text))
+}
@@ -177,3 +177,126 @@ func TestFrequencyMHz_RoundTrip(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFrequency(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Frequency
+		wantErr  bool
+	}{
+		{"146.52", 146520000, false},
+		{"146520k", 146520000, false},
+		{"146520kHz", 146520000, false},
+		{"146520000Hz", 146520000, false},
+		{"146.52MHz", 146520000, false},
+		{"146.52 mhz", 146520000, false},
+		{"0.6", 600000, false},
+		{"600k", 600000, false},
+		{"446.00625", 446006250, false},
+		{"146.5200000", 146520000, false},
+		{".5", 500000, false},
+		{"12.5k", 12500, false},
+		{"146.5200001", 0, true},
+		{"100.5Hz", 0, true},
+		{"1.2.3", 0, true},
+		{"146.52GHz", 0, true},
+		{"MHz", 0, true},
+		{"-1k", 0, true},
+		{"1e6", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			f, err := ParseFrequency(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFrequency(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && f != tt.expected {
+				t.Errorf("ParseFrequency(%q) = %d, expected %d", tt.input, f, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFrequency_Format(t *testing.T) {
+	tests := []struct {
+		freq      Frequency
+		precision int
+		expected  string
+	}{
+		{146520000, 6, "146.520000"},
+		{146520000, 3, "146.520"},
+		{446006250, 3, "446.006"},
+		{446006750, 3, "446.007"},
+		{146520000, 0, "147"},
+		{999, 3, "0.001"},
+		{118008333, 4, "118.0083"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.freq.Format(tt.precision); got != tt.expected {
+			t.Errorf("Frequency(%d).Format(%d) = %s, expected %s", tt.freq, tt.precision, got, tt.expected)
+		}
+	}
+}
+
+func TestFrequency_Snap(t *testing.T) {
+	tests := []struct {
+		name     string
+		freq     Frequency
+		step     string
+		expected Frequency
+	}{
+		{"on 5k grid", 146520000, "5", 146520000},
+		{"off 5k grid", 146521000, "5", 146520000},
+		{"round up", 146523000, "5", 146525000},
+		{"6.25k", 446005000, "6.25", 446006250},
+		{"12.5k", 446005000, "12.5", 446000000},
+		{"8.33k", 118010000, "28.33", 118008333},
+		{"8.33k on grid", 118008333, "28.33", 118008333},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var step int
+			if err := NewStepSize(&step).Set(tt.step); err != nil {
+				t.Fatalf("invalid step %s: %v", tt.step, err)
+			}
+			if got := tt.freq.Snap(step); got != tt.expected {
+				t.Errorf("Snap() = %d, expected %d", got, tt.expected)
+			}
+			if got := tt.freq.OnGrid(step); got != (tt.freq == tt.expected) {
+				t.Errorf("OnGrid() = %v, expected %v", got, tt.freq == tt.expected)
+			}
+		})
+	}
+}
+
+func TestFrequency_AddSteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		freq     Frequency
+		n        int
+		step     string
+		expected Frequency
+	}{
+		{"up one", 146520000, 1, "5", 146525000},
+		{"down three", 146520000, -3, "5", 146505000},
+		{"aligns first", 146521000, 1, "5", 146525000},
+		{"12.5k", 446000000, 2, "12.5", 446025000},
+		{"8.33k", 118000000, 1, "28.33", 118008333},
+		{"8.33k three steps", 118000000, 3, "28.33", 118025000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var step int
+			if err := NewStepSize(&step).Set(tt.step); err != nil {
+				t.Fatalf("invalid step %s: %v", tt.step, err)
+			}
+			if got := tt.freq.AddSteps(tt.n, step); got != tt.expected {
+				t.Errorf("AddSteps(%d) = %d, expected %d", tt.n, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// Key is a microphone or front panel key that can be pressed
+	// remotely. KEY_0 through KEY_9 are the digit keys, with values equal
+	// to the digit.
+	Key int
+)
+
+const (
+	KEY_0 Key = iota
+	KEY_1
+	KEY_2
+	KEY_3
+	KEY_4
+	KEY_5
+	KEY_6
+	KEY_7
+	KEY_8
+	KEY_9
+	KEY_UP
+	KEY_DOWN
+	KEY_VFO
+	KEY_MR
+	KEY_CALL
+)
+
+var (
+	keyNames = map[string]Key{
+		"UP":   KEY_UP,
+		"DOWN": KEY_DOWN,
+		"DW":   KEY_DOWN,
+		"VFO":  KEY_VFO,
+		"MR":   KEY_MR,
+		"CALL": KEY_CALL,
+	}
+
+	// unsupportedKeys are keys on the radio or microphone for which the
+	// CAT protocol has no equivalent command.
+	unsupportedKeys = []string{"PF1", "PF2", "PF3", "PF4", "*", "#"}
+)
+
+func (k Key) String() string {
+	switch {
+	case k.IsDigit():
+		return fmt.Sprintf("%d", k)
+	case k == KEY_UP:
+		return "UP"
+	case k == KEY_DOWN:
+		return "DOWN"
+	case k == KEY_VFO:
+		return "VFO"
+	case k == KEY_MR:
+		return "MR"
+	case k == KEY_CALL:
+		return "CALL"
+	default:
+		return "<invalid>"
+	}
+}
+
+// IsDigit returns true for the keys KEY_0 through KEY_9.
+func (k Key) IsDigit() bool {
+	return k >= KEY_0 && k <= KEY_9
+}
+
+// ParseKey parses a key name (ignoring case) or a single digit.
+func ParseKey(s string) (Key, error) {
+	name := strings.ToUpper(s)
+
+	if len(name) == 1 && name[0] >= '0' && name[0] <= '9' {
+		return Key(name[0] - '0'), nil
+	}
+	if key, exists := keyNames[name]; exists {
+		return key, nil
+	}
+	for _, unsupported := range unsupportedKeys {
+		if name == unsupported {
+			return 0, fmt.Errorf("key %s cannot be pressed remotely", name)
+		}
+	}
+
+	return 0, fmt.Errorf("invalid key: %s", s)
+}
+
+// ParseKeySequence parses a list of key names. A word made up only of
+// digits is a sequence of digit keys, so "MR 146" is the same as
+// "MR 1 4 6".
+func ParseKeySequence(words []string) ([]Key, error) {
+	var keys []Key
+
+	for _, word := range words {
+		if word != "" && strings.Trim(word, "0123456789") == "" {
+			for _, digit := range word {
+				keys = append(keys, Key(digit-'0'))
+			}
+			continue
+		}
+
+		key, err := ParseKey(word)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
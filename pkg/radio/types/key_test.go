@@ -0,0 +1,24 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKeySequence(t *testing.T) {
+	keys, err := ParseKeySequence([]string{"MR", "1", "46", "dw", "Call"})
+	if err != nil {
+		t.Fatalf("ParseKeySequence() failed: %v", err)
+	}
+
+	expected := []Key{KEY_MR, KEY_1, KEY_4, KEY_6, KEY_DOWN, KEY_CALL}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("have %v, expected %v", keys, expected)
+	}
+
+	for _, word := range []string{"PF1", "#", "MENU", "-1"} {
+		if _, err := ParseKeySequence([]string{word}); err == nil {
+			t.Errorf("expected error parsing %q", word)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Channels and VFOs are marshalled to JSON and YAML using human units
+// rather than the raw protocol codes, e.g.:
+//
+//	{"rxFreq":"146.820","shift":"down","toneFreq":"146.2","mode":"FM"}
+//
+// Decoding validates every value, so invalid codes are rejected. Fields
+// that are missing (or empty) keep their zero value. A combination of the
+// tone, ctcss and dcs status fields that is not a tone mode is written as
+// toneFlags instead of toneMode, so that it survives a round trip.
+
+type (
+	vfoDocument struct {
+		VFO       int        `json:"vfo" yaml:"vfo"`
+		RxFreq    Frequency  `json:"rxFreq" yaml:"rxFreq"`
+		RxStep    string     `json:"rxStep" yaml:"rxStep"`
+		Shift     string     `json:"shift" yaml:"shift"`
+		Reverse   bool       `json:"reverse" yaml:"reverse"`
+		ToneMode  *ToneMode  `json:"toneMode,omitempty" yaml:"toneMode,omitempty"`
+		ToneFlags *toneFlags `json:"toneFlags,omitempty" yaml:"toneFlags,omitempty"`
+		ToneFreq  string     `json:"toneFreq" yaml:"toneFreq"`
+		CTCSSFreq string     `json:"ctcssFreq" yaml:"ctcssFreq"`
+		DCSCode   string     `json:"dcsCode" yaml:"dcsCode"`
+		Offset    Frequency  `json:"offset" yaml:"offset"`
+		Mode      string     `json:"mode" yaml:"mode"`
+	}
+
+	channelDocument struct {
+		Number    int        `json:"number" yaml:"number"`
+		Name      string     `json:"name" yaml:"name"`
+		RxFreq    Frequency  `json:"rxFreq" yaml:"rxFreq"`
+		RxStep    string     `json:"rxStep" yaml:"rxStep"`
+		Shift     string     `json:"shift" yaml:"shift"`
+		Reverse   bool       `json:"reverse" yaml:"reverse"`
+		ToneMode  *ToneMode  `json:"toneMode,omitempty" yaml:"toneMode,omitempty"`
+		ToneFlags *toneFlags `json:"toneFlags,omitempty" yaml:"toneFlags,omitempty"`
+		ToneFreq  string     `json:"toneFreq" yaml:"toneFreq"`
+		CTCSSFreq string     `json:"ctcssFreq" yaml:"ctcssFreq"`
+		DCSCode   string     `json:"dcsCode" yaml:"dcsCode"`
+		Offset    Frequency  `json:"offset" yaml:"offset"`
+		Mode      string     `json:"mode" yaml:"mode"`
+		TxFreq    Frequency  `json:"txFreq" yaml:"txFreq"`
+		TxStep    string     `json:"txStep" yaml:"txStep"`
+		Lockout   bool       `json:"lockout" yaml:"lockout"`
+	}
+
+	// toneFlags holds the raw tone status fields when they do not
+	// correspond to a tone mode.
+	toneFlags struct {
+		Tone  bool `json:"tone" yaml:"tone"`
+		CTCSS bool `json:"ctcss" yaml:"ctcss"`
+		DCS   bool `json:"dcs" yaml:"dcs"`
+	}
+
+	// textField pairs a document value with the flag type that parses it.
+	textField struct {
+		name  string
+		value string
+		dest  interface{ Set(string) error }
+	}
+)
+
+func boolCode(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// setTextFields parses each non-empty value into its destination.
+func setTextFields(fields []textField) error {
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		if err := f.dest.Set(f.value); err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+// toneDocument returns the tone mode of s, or its raw tone status fields
+// if they are not a valid tone mode.
+func toneDocument(s RadioSettable) (*ToneMode, *toneFlags) {
+	mode := GetToneMode(s)
+	if mode == TONE_MODE_INVALID {
+		return nil, &toneFlags{s.GetTone() != 0, s.GetCTCSS() != 0, s.GetDCS() != 0}
+	}
+	return &mode, nil
+}
+
+// setToneDocument sets the tone status fields of s from either a tone mode
+// or raw tone flags.
+func setToneDocument(s RadioSettable, mode *ToneMode, flags *toneFlags) error {
+	switch {
+	case mode != nil && flags != nil:
+		return fmt.Errorf("toneMode and toneFlags cannot both be given")
+	case mode != nil:
+		return SetToneMode(s, *mode)
+	case flags != nil:
+		s.SetTone(boolCode(flags.Tone))
+		s.SetCTCSS(boolCode(flags.CTCSS))
+		s.SetDCS(boolCode(flags.DCS))
+	}
+	return nil
+}
+
+func (v VFO) document() vfoDocument {
+	d := vfoDocument{
+		VFO:       v.VFO,
+		RxFreq:    Frequency(v.RxFreq),
+		RxStep:    NewStepSize(&v.RxStep).String(),
+		Shift:     NewShift(&v.Shift).String(),
+		Reverse:   v.Reverse != 0,
+		ToneFreq:  NewTone(&v.ToneFreq).String(),
+		CTCSSFreq: NewTone(&v.CTCSSFreq).String(),
+		DCSCode:   NewDCS(&v.DCSCode).String(),
+		Offset:    Frequency(v.Offset),
+		Mode:      NewMode(&v.Mode).String(),
+	}
+	d.ToneMode, d.ToneFlags = toneDocument(&v)
+	return d
+}
+
+func (d vfoDocument) vfo() (VFO, error) {
+	v := VFO{
+		VFO:     d.VFO,
+		RxFreq:  int(d.RxFreq),
+		Reverse: boolCode(d.Reverse),
+		Offset:  int(d.Offset),
+	}
+	err := setTextFields([]textField{
+		{"rxStep", d.RxStep, NewStepSize(&v.RxStep)},
+		{"shift", d.Shift, NewShift(&v.Shift)},
+		{"toneFreq", d.ToneFreq, NewTone(&v.ToneFreq)},
+		{"ctcssFreq", d.CTCSSFreq, NewTone(&v.CTCSSFreq)},
+		{"dcsCode", d.DCSCode, NewDCS(&v.DCSCode)},
+		{"mode", d.Mode, NewMode(&v.Mode)},
+	})
+	if err == nil {
+		err = setToneDocument(&v, d.ToneMode, d.ToneFlags)
+	}
+	if err != nil {
+		return VFO{}, fmt.Errorf("invalid vfo: %w", err)
+	}
+	return v, nil
+}
+
+func (c Channel) document() channelDocument {
+	d := channelDocument{
+		Number:    c.Number,
+		Name:      c.Name,
+		RxFreq:    Frequency(c.RxFreq),
+		RxStep:    NewStepSize(&c.RxStep).String(),
+		Shift:     NewShift(&c.Shift).String(),
+		Reverse:   c.Reverse != 0,
+		ToneFreq:  NewTone(&c.ToneFreq).String(),
+		CTCSSFreq: NewTone(&c.CTCSSFreq).String(),
+		DCSCode:   NewDCS(&c.DCSCode).String(),
+		Offset:    Frequency(c.Offset),
+		Mode:      NewMode(&c.Mode).String(),
+		TxFreq:    Frequency(c.TxFreq),
+		TxStep:    NewStepSize(&c.TxStep).String(),
+		Lockout:   c.Lockout != 0,
+	}
+	d.ToneMode, d.ToneFlags = toneDocument(&c)
+	return d
+}
+
+func (d channelDocument) channel() (Channel, error) {
+	c := Channel{
+		Number:  d.Number,
+		Name:    d.Name,
+		RxFreq:  int(d.RxFreq),
+		Reverse: boolCode(d.Reverse),
+		Offset:  int(d.Offset),
+		TxFreq:  int(d.TxFreq),
+		Lockout: boolCode(d.Lockout),
+	}
+	err := setTextFields([]textField{
+		{"rxStep", d.RxStep, NewStepSize(&c.RxStep)},
+		{"shift", d.Shift, NewShift(&c.Shift)},
+		{"toneFreq", d.ToneFreq, NewTone(&c.ToneFreq)},
+		{"ctcssFreq", d.CTCSSFreq, NewTone(&c.CTCSSFreq)},
+		{"dcsCode", d.DCSCode, NewDCS(&c.DCSCode)},
+		{"mode", d.Mode, NewMode(&c.Mode)},
+		{"txStep", d.TxStep, NewStepSize(&c.TxStep)},
+	})
+	if err == nil {
+		err = setToneDocument(&c, d.ToneMode, d.ToneFlags)
+	}
+	if err != nil {
+		return Channel{}, fmt.Errorf("invalid channel: %w", err)
+	}
+	return c, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v VFO) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.document())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *VFO) UnmarshalJSON(data []byte) error {
+	var d vfoDocument
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	val, err := d.vfo()
+	if err != nil {
+		return err
+	}
+	*v = val
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (v VFO) MarshalYAML() (any, error) {
+	return v.document(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (v *VFO) UnmarshalYAML(node *yaml.Node) error {
+	var d vfoDocument
+	if err := node.Decode(&d); err != nil {
+		return err
+	}
+	val, err := d.vfo()
+	if err != nil {
+		return err
+	}
+	*v = val
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c Channel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.document())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Channel) UnmarshalJSON(data []byte) error {
+	var d channelDocument
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	val, err := d.channel()
+	if err != nil {
+		return err
+	}
+	*c = val
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (c Channel) MarshalYAML() (any, error) {
+	return c.document(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (c *Channel) UnmarshalYAML(node *yaml.Node) error {
+	var d channelDocument
+	if err := node.Decode(&d); err != nil {
+		return err
+	}
+	val, err := d.channel()
+	if err != nil {
+		return err
+	}
+	*c = val
+	return nil
+}
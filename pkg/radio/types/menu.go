@@ -0,0 +1,300 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+/*
+The MU command reads and writes the radio menu settings as a single list of
+42 comma separated values. The layout follows the TM-D710 MU structure used
+by hamlib, which has not been checked field by field against a TM-V71. A
+wrong position would show or change a different menu item, so only the
+settings that hamlib also reads and writes on the TM-V71 (beep, brightness
+and auto power off) are supported. The other fields are parsed only so that
+they can be written back exactly as they were read.
+
+The power-on message is not part of the MU command, and there is no CAT
+command to read or set it.
+
+1 	Beep
+2 	Beep volume (1-7)
+3 	External speaker mode
+4 	Announce
+5 	Language
+6 	Voice volume (0-7)
+7 	Voice speed (0-4)
+8 	Playback repeat
+9 	Playback repeat interval in seconds 2 digit
+10 	Continuous recording
+11 	VHF AIP
+12 	UHF AIP
+13 	S-meter squelch hang time
+14 	Mute hang time
+15 	Beat shift
+16 	Time-out timer
+17 	Recall method
+18 	EchoLink speed
+19 	DTMF hold
+20 	DTMF speed
+21 	DTMF pause
+22 	DTMF key lock
+23 	Auto repeater offset
+24 	1750 Hz tone TX hold
+25 	Unknown, 2 digit hex
+26 	Display brightness (0-8)
+27 	Auto brightness
+28 	Backlight color
+29 	PF1 key function 2 digit
+30 	PF2 key function 2 digit
+31 	Mic PF1 key function 2 digit
+32 	Mic PF2 key function 2 digit
+33 	Mic PF3 key function 2 digit
+34 	Mic PF4 key function 2 digit
+35 	Mic key lock
+36 	Scan resume
+37 	Auto power off
+38 	External data band
+39 	External data speed
+40 	SQC output source
+41 	Auto PM store
+42 	Display partition bar
+*/
+
+type (
+	Menu struct {
+		Beep                   int
+		BeepVolume             int
+		ExtSpeakerMode         int
+		Announce               int
+		Language               int
+		VoiceVolume            int
+		VoiceSpeed             int
+		PlaybackRepeat         int
+		PlaybackRepeatInterval int
+		ContinuousRecording    int
+		VhfAip                 int
+		UhfAip                 int
+		SmeterSqlHangTime      int
+		MuteHangTime           int
+		BeatShift              int
+		TimeoutTimer           int
+		RecallMethod           int
+		EchoLinkSpeed          int
+		DtmfHold               int
+		DtmfSpeed              int
+		DtmfPause              int
+		DtmfKeyLock            int
+		AutoRepeaterOffset     int
+		Tone1750TxHold         int
+		Unknown25              int
+		Brightness             int
+		AutoBrightness         int
+		BacklightColor         int
+		Pf1Key                 int
+		Pf2Key                 int
+		MicPf1Key              int
+		MicPf2Key              int
+		MicPf3Key              int
+		MicPf4Key              int
+		MicKeyLock             int
+		ScanResume             int
+		AutoPowerOff           int
+		ExtDataBand            int
+		ExtDataSpeed           int
+		SqcSource              int
+		AutoPMStore            int
+		DisplayPartitionBar    int
+	}
+
+	// menuField describes how a Menu field is named, serialized and
+	// presented. Values are either booleans, one of a list of names (the
+	// value is the index into names), or an integer between min and max.
+	// Fields that are not confirmed for the TM-V71 are hidden.
+	menuField struct {
+		key       string
+		format    string
+		boolean   bool
+		names     []string
+		min, max  int
+		confirmed bool
+	}
+)
+
+var EmptyMenu = Menu{}
+
+// menuFields lists the Menu fields in the order in which they appear in
+// the MU command.
+var menuFields = []menuField{
+	{key: "beep", format: "%d", boolean: true, confirmed: true},
+	{key: "beep-volume", format: "%d", min: 1, max: 7},
+	{key: "ext-speaker-mode", format: "%d", names: []string{"mode1", "mode2"}},
+	{key: "announce", format: "%d", names: []string{"off", "auto", "manual"}},
+	{key: "language", format: "%d", names: []string{"english", "japanese"}},
+	{key: "voice-volume", format: "%d", min: 0, max: 7},
+	{key: "voice-speed", format: "%d", min: 0, max: 4},
+	{key: "playback-repeat", format: "%d", boolean: true},
+	{key: "playback-repeat-interval", format: "%02d", min: 0, max: 60},
+	{key: "continuous-recording", format: "%d", boolean: true},
+	{key: "vhf-aip", format: "%d", boolean: true},
+	{key: "uhf-aip", format: "%d", boolean: true},
+	{key: "smeter-sql-hang-time", format: "%d", names: []string{"off", "125ms", "250ms", "500ms"}},
+	{key: "mute-hang-time", format: "%d", names: []string{"off", "125ms", "250ms", "500ms", "750ms", "1000ms"}},
+	{key: "beat-shift", format: "%d", boolean: true},
+	{key: "timeout-timer", format: "%d", names: []string{"3min", "5min", "10min"}},
+	{key: "recall-method", format: "%d", names: []string{"all", "current"}},
+	{key: "echolink-speed", format: "%d", names: []string{"fast", "slow"}},
+	{key: "dtmf-hold", format: "%d", boolean: true},
+	{key: "dtmf-speed", format: "%d", names: []string{"fast", "slow"}},
+	{key: "dtmf-pause", format: "%d", names: []string{"100ms", "250ms", "500ms", "750ms", "1000ms", "1500ms", "2000ms"}},
+	{key: "dtmf-key-lock", format: "%d", boolean: true},
+	{key: "auto-repeater-offset", format: "%d", boolean: true},
+	{key: "tone-1750-tx-hold", format: "%d", boolean: true},
+	{key: "unknown-25", format: "%02X", min: 0, max: 0xff},
+	{key: "brightness", format: "%d", min: 0, max: 8, confirmed: true},
+	{key: "auto-brightness", format: "%d", boolean: true},
+	{key: "backlight-color", format: "%d", names: []string{"amber", "green"}},
+	{key: "pf1-key", format: "%02d", min: 0, max: 99},
+	{key: "pf2-key", format: "%02d", min: 0, max: 99},
+	{key: "mic-pf1-key", format: "%02d", min: 0, max: 99},
+	{key: "mic-pf2-key", format: "%02d", min: 0, max: 99},
+	{key: "mic-pf3-key", format: "%02d", min: 0, max: 99},
+	{key: "mic-pf4-key", format: "%02d", min: 0, max: 99},
+	{key: "mic-key-lock", format: "%d", boolean: true},
+	{key: "scan-resume", format: "%d", names: []string{"time", "carrier", "seek"}},
+	{key: "auto-power-off", format: "%d", names: []string{"off", "30min", "60min", "90min", "120min", "180min"}, confirmed: true},
+	{key: "ext-data-band", format: "%d", names: []string{"a", "b", "tx-a-rx-b", "tx-b-rx-a"}},
+	{key: "ext-data-speed", format: "%d", names: []string{"1200", "9600"}},
+	{key: "sqc-source", format: "%d", names: []string{"off", "busy", "sql", "tx", "busy-or-tx", "sql-or-tx"}},
+	{key: "auto-pm-store", format: "%d", boolean: true},
+	{key: "display-partition-bar", format: "%d", boolean: true},
+}
+
+// MU 1,3,0,0,0,4,2,0,10,0,0,0,0,0,0,1,0,0,0,0,0,0,1,0,00,5,0,0,00,01,02,03,04,05,0,0,0,0,0,0,0,0
+func ParseMenu(s string) (Menu, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != len(menuFields) {
+		return EmptyMenu, fmt.Errorf("invalid menu specification: expected %d fields, got %d", len(menuFields), len(parts))
+	}
+
+	var m Menu
+	values := reflect.ValueOf(&m).Elem()
+	for i, field := range menuFields {
+		base := 10
+		if field.format == "%02X" {
+			base = 16
+		}
+		ival, err := strconv.ParseInt(parts[i], base, 0)
+		if err != nil {
+			return EmptyMenu, fmt.Errorf("invalid value for %s: %w", field.key, err)
+		}
+		values.Field(i).SetInt(ival)
+	}
+
+	return m, nil
+}
+
+// Produce format expected by radio commands
+func (m Menu) Serialize() string {
+	values := reflect.ValueOf(m)
+	parts := make([]string, len(menuFields))
+	for i, field := range menuFields {
+		parts[i] = fmt.Sprintf(field.format, values.Field(i).Int())
+	}
+	return strings.Join(parts, ",")
+}
+
+// MenuKeys returns the names of the supported menu settings in radio order.
+func MenuKeys() []string {
+	var keys []string
+	for _, field := range menuFields {
+		if field.confirmed {
+			keys = append(keys, field.key)
+		}
+	}
+	return keys
+}
+
+// Get returns the human friendly value of the named menu setting.
+func (m Menu) Get(key string) (string, error) {
+	i, field, err := lookupMenuField(key)
+	if err != nil {
+		return "", err
+	}
+
+	val := int(reflect.ValueOf(m).Field(i).Int())
+	switch {
+	case field.boolean:
+		return NewBool(&val).String(), nil
+	case field.names != nil:
+		if val < 0 || val >= len(field.names) {
+			return fmt.Sprintf("<invalid:%d>", val), nil
+		}
+		return field.names[val], nil
+	default:
+		return fmt.Sprintf("%d", val), nil
+	}
+}
+
+// Set parses value and stores it in the named menu setting.
+func (m *Menu) Set(key, value string) error {
+	i, field, err := lookupMenuField(key)
+	if err != nil {
+		return err
+	}
+
+	var val int
+	switch {
+	case field.boolean:
+		if err := NewBool(&val).Set(value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	case field.names != nil:
+		val = slices.Index(field.names, strings.ToLower(value))
+		if val < 0 {
+			return fmt.Errorf("%s: invalid value %q (must be one of %s)", key, value, strings.Join(field.names, ", "))
+		}
+	default:
+		val, err = strconv.Atoi(value)
+		if err != nil || val < field.min || val > field.max {
+			return fmt.Errorf("%s: invalid value %q (must be between %d and %d)", key, value, field.min, field.max)
+		}
+	}
+
+	reflect.ValueOf(m).Elem().Field(i).SetInt(int64(val))
+	return nil
+}
+
+// MenuAllowedValues returns a description of the values accepted by the
+// named menu setting.
+func MenuAllowedValues(key string) (string, error) {
+	_, field, err := lookupMenuField(key)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case field.boolean:
+		return "true, false", nil
+	case field.names != nil:
+		return strings.Join(field.names, ", "), nil
+	default:
+		return fmt.Sprintf("%d-%d", field.min, field.max), nil
+	}
+}
+
+func lookupMenuField(key string) (int, menuField, error) {
+	for i, field := range menuFields {
+		if field.key != key {
+			continue
+		}
+		if !field.confirmed {
+			return 0, menuField{}, fmt.Errorf("menu setting %s is not supported (its position in the MU command is not confirmed for the TM-V71)", key)
+		}
+		return i, field, nil
+	}
+	return 0, menuField{}, fmt.Errorf("unknown menu setting: %s", key)
+}
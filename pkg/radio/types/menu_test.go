@@ -0,0 +1,97 @@
+package types
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+const testMenu = "1,3,0,0,0,4,2,0,10,0,0,0,0,0,0,1,0,0,0,0,0,0,1,0,0A,5,0,1,00,01,02,03,04,05,0,0,2,0,1,0,0,0"
+
+func TestMenuFieldsMatchStruct(t *testing.T) {
+	if n := reflect.TypeOf(Menu{}).NumField(); n != len(menuFields) {
+		t.Errorf("Menu has %d fields but menuFields describes %d", n, len(menuFields))
+	}
+}
+
+func TestParseMenu(t *testing.T) {
+	m, err := ParseMenu(testMenu)
+	if err != nil {
+		t.Fatalf("expected success, failed with: %v", err)
+	}
+
+	if m.Beep != 1 || m.BeepVolume != 3 || m.PlaybackRepeatInterval != 10 || m.Unknown25 != 10 ||
+		m.BacklightColor != 1 || m.MicPf4Key != 5 || m.AutoPowerOff != 2 || m.ExtDataSpeed != 1 {
+		t.Errorf("unexpected result: %+v", m)
+	}
+
+	if raw := m.Serialize(); raw != testMenu {
+		t.Errorf("have %s, expected %s", raw, testMenu)
+	}
+
+	if _, err := ParseMenu("1,3,0"); err == nil {
+		t.Errorf("expected error for short menu")
+	}
+}
+
+func TestMenuGetSet(t *testing.T) {
+	m, err := ParseMenu(testMenu)
+	if err != nil {
+		t.Fatalf("expected success, failed with: %v", err)
+	}
+
+	tests := []struct {
+		key, value string
+		valid      bool
+	}{
+		{"beep", "false", true},
+		{"brightness", "8", true},
+		{"brightness", "9", false},
+		{"auto-power-off", "180min", true},
+		{"auto-power-off", "forever", false},
+		{"backlight-color", "amber", false},
+		{"no-such-setting", "1", false},
+	}
+
+	for _, tt := range tests {
+		err := m.Set(tt.key, tt.value)
+		if err != nil && tt.valid {
+			t.Errorf("%s=%s: expected success, failed with: %v", tt.key, tt.value, err)
+		} else if err == nil && !tt.valid {
+			t.Errorf("%s=%s: expected error", tt.key, tt.value)
+		}
+
+		if tt.valid {
+			have, err := m.Get(tt.key)
+			if err != nil {
+				t.Errorf("%s: failed to get value: %v", tt.key, err)
+			} else if have != tt.value {
+				t.Errorf("%s: have %s, expected %s", tt.key, have, tt.value)
+			}
+		}
+	}
+
+	if m.AutoPowerOff != 5 {
+		t.Errorf("AutoPowerOff: have %d, expected 5", m.AutoPowerOff)
+	}
+}
+
+func TestMenuUnconfirmed(t *testing.T) {
+	m, err := ParseMenu(testMenu)
+	if err != nil {
+		t.Fatalf("expected success, failed with: %v", err)
+	}
+
+	if keys := MenuKeys(); !slices.Equal(keys, []string{"beep", "brightness", "auto-power-off"}) {
+		t.Errorf("unexpected keys: %v", keys)
+	}
+	if _, err := m.Get("beep-volume"); err == nil {
+		t.Errorf("expected error getting unconfirmed setting")
+	}
+	if err := m.Set("beep-volume", "7"); err == nil {
+		t.Errorf("expected error setting unconfirmed setting")
+	}
+	if m.BeepVolume != 3 {
+		t.Errorf("BeepVolume: have %d, expected 3", m.BeepVolume)
+	}
+}
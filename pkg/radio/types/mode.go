@@ -18,6 +18,22 @@ var modeForward map[int]string = map[int]string{
 
 var modeReverse map[string]int = tools.ReverseMap(modeForward)
 
+// modeBandwidth is the occupied bandwidth (in Hz) of each mode.
+var modeBandwidth map[int]int = map[int]int{
+	0: 16_000,
+	1: 11_000,
+	2: 6_000,
+}
+
+// ModeBandwidth returns the occupied bandwidth (in Hz) of a signal in the
+// given mode. Unknown modes are assumed to be as wide as FM.
+func ModeBandwidth(mode int) int {
+	if bw, exists := modeBandwidth[mode]; exists {
+		return bw
+	}
+	return modeBandwidth[0]
+}
+
 func NewMode(modePtr *int) *Mode {
 	return &Mode{valuePtr: modePtr}
 }
@@ -47,3 +63,13 @@ func (m *Mode) Set(value string) error {
 func (m *Mode) Type() string {
 	return "mode"
 }
+
+// MarshalText implements encoding.TextMarshaler.
+func (m *Mode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *Mode) UnmarshalText(text []byte) error {
+	return m.Set(string(text))
+}
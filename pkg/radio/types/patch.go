@@ -0,0 +1,246 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	// SettingsPatch describes a partial change to a RadioSettable. Only
+	// the fields that are non-nil are applied; everything else is left
+	// unchanged. Values use the same protocol codes as Channel and VFO.
+	SettingsPatch struct {
+		RxFreq    *int
+		RxStep    *int
+		Mode      *int
+		Shift     *int
+		Reverse   *bool
+		Offset    *int
+		ToneMode  *ToneMode
+		ToneFreq  *int
+		CTCSSFreq *int
+		DCSCode   *int
+
+		// Channel-only fields; these can only be applied to a *Channel.
+		TxFreq  *int
+		TxStep  *int
+		Lockout *bool
+		Name    *string
+
+		// Snap rounds frequencies that are not a multiple of the step
+		// size to the nearest step instead of rejecting them.
+		Snap bool
+	}
+
+	// patchDocument is the JSON form of a SettingsPatch, in the same
+	// human units as the JSON form of Channel.
+	patchDocument struct {
+		RxFreq    patchField[Frequency] `json:"rxFreq"`
+		RxStep    patchField[string]    `json:"rxStep"`
+		Mode      patchField[string]    `json:"mode"`
+		Shift     patchField[string]    `json:"shift"`
+		Reverse   patchField[bool]      `json:"reverse"`
+		Offset    patchField[Frequency] `json:"offset"`
+		ToneMode  patchField[ToneMode]  `json:"toneMode"`
+		ToneFreq  patchField[string]    `json:"toneFreq"`
+		CTCSSFreq patchField[string]    `json:"ctcssFreq"`
+		DCSCode   patchField[string]    `json:"dcsCode"`
+		TxFreq    patchField[Frequency] `json:"txFreq"`
+		TxStep    patchField[string]    `json:"txStep"`
+		Lockout   patchField[bool]      `json:"lockout"`
+		Name      patchField[string]    `json:"name"`
+		Snap      bool                  `json:"snap"`
+	}
+
+	// patchField is a member of a patch document, which may be absent,
+	// null or have a value.
+	patchField[T any] struct {
+		present bool
+		null    bool
+		value   T
+	}
+)
+
+// UnmarshalJSON implements json.Unmarshaler. It is only called for members
+// that are present, including those that are null.
+func (f *patchField[T]) UnmarshalJSON(data []byte) error {
+	f.present = true
+	if string(data) == "null" {
+		f.null = true
+		return nil
+	}
+	return json.Unmarshal(data, &f.value)
+}
+
+// get returns nil if the member is absent, the zero value if it is null,
+// and its value otherwise.
+func (f patchField[T]) get() *T {
+	if !f.present {
+		return nil
+	}
+	if f.null {
+		return new(T)
+	}
+	return &f.value
+}
+
+// ParseSettingsPatch parses a JSON merge patch (RFC 7396) such as
+// {"rxFreq":"146.820","shift":"down","toneMode":"tone","toneFreq":"146.2"}.
+// Members that are absent leave the corresponding setting unchanged. A
+// null member resets the setting to its zero value, as in an empty
+// Channel: 5 kHz step, FM, simplex, no reverse, no offset, tone mode none,
+// 67.0 Hz tones, DCS 023, no separate transmit frequency, no lockout and no
+// name. The receive frequency has no zero value, so it cannot be null.
+// Unknown members and invalid values are rejected.
+func ParseSettingsPatch(data []byte) (SettingsPatch, error) {
+	var doc patchDocument
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&doc); err != nil {
+		return SettingsPatch{}, fmt.Errorf("invalid settings patch: %w", err)
+	}
+	if doc.RxFreq.null {
+		return SettingsPatch{}, fmt.Errorf("invalid settings patch: rxFreq cannot be null")
+	}
+
+	p := SettingsPatch{
+		RxFreq:   frequencyCode(doc.RxFreq.get()),
+		Reverse:  doc.Reverse.get(),
+		Offset:   frequencyCode(doc.Offset.get()),
+		ToneMode: doc.ToneMode.get(),
+		TxFreq:   frequencyCode(doc.TxFreq.get()),
+		Lockout:  doc.Lockout.get(),
+		Name:     doc.Name.get(),
+		Snap:     doc.Snap,
+	}
+
+	var err error
+	if p.RxStep, err = parseCode(doc.RxStep, NewStepSize); err != nil {
+		return SettingsPatch{}, fmt.Errorf("invalid settings patch: rxStep: %w", err)
+	}
+	if p.Mode, err = parseCode(doc.Mode, NewMode); err != nil {
+		return SettingsPatch{}, fmt.Errorf("invalid settings patch: mode: %w", err)
+	}
+	if p.Shift, err = parseCode(doc.Shift, NewShift); err != nil {
+		return SettingsPatch{}, fmt.Errorf("invalid settings patch: shift: %w", err)
+	}
+	if p.ToneFreq, err = parseCode(doc.ToneFreq, NewTone); err != nil {
+		return SettingsPatch{}, fmt.Errorf("invalid settings patch: toneFreq: %w", err)
+	}
+	if p.CTCSSFreq, err = parseCode(doc.CTCSSFreq, NewTone); err != nil {
+		return SettingsPatch{}, fmt.Errorf("invalid settings patch: ctcssFreq: %w", err)
+	}
+	if p.DCSCode, err = parseCode(doc.DCSCode, NewDCS); err != nil {
+		return SettingsPatch{}, fmt.Errorf("invalid settings patch: dcsCode: %w", err)
+	}
+	if p.TxStep, err = parseCode(doc.TxStep, NewStepSize); err != nil {
+		return SettingsPatch{}, fmt.Errorf("invalid settings patch: txStep: %w", err)
+	}
+
+	return p, nil
+}
+
+// parseCode parses an optional value using one of the flag types. A null
+// value is code 0.
+func parseCode[T interface{ Set(string) error }](value patchField[string], wrap func(*int) T) (*int, error) {
+	if !value.present {
+		return nil, nil
+	}
+	code := new(int)
+	if value.null {
+		return code, nil
+	}
+	if err := wrap(code).Set(value.value); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+func frequencyCode(f *Frequency) *int {
+	if f == nil {
+		return nil
+	}
+	hz := int(*f)
+	return &hz
+}
+
+// Apply applies the patch to target. If the receive frequency or step is
+// changed, the resulting frequency must be a multiple of the step (or is
+// rounded to it if Snap is set); the same applies to the transmit
+// frequency of a channel. Channel-only fields are an error for any other
+// target. On error, target may have been partially modified.
+func (p SettingsPatch) Apply(target RadioSettable) error {
+	channel, isChannel := target.(*Channel)
+	if !isChannel && (p.TxFreq != nil || p.TxStep != nil || p.Lockout != nil || p.Name != nil) {
+		return fmt.Errorf("txfreq, txstep, lockout and name can only be set on a memory channel")
+	}
+
+	if p.RxFreq != nil {
+		target.SetRxFreq(*p.RxFreq)
+	}
+	if p.RxStep != nil {
+		target.SetRxStep(*p.RxStep)
+	}
+	if p.Mode != nil {
+		target.SetMode(*p.Mode)
+	}
+	if p.Shift != nil {
+		target.SetShift(*p.Shift)
+	}
+	if p.Reverse != nil {
+		target.SetReverse(boolCode(*p.Reverse))
+	}
+	if p.Offset != nil {
+		target.SetOffset(*p.Offset)
+	}
+	if p.ToneMode != nil {
+		if err := SetToneMode(target, *p.ToneMode); err != nil {
+			return err
+		}
+	}
+	if p.ToneFreq != nil {
+		target.SetToneFreq(*p.ToneFreq)
+	}
+	if p.CTCSSFreq != nil {
+		target.SetCTCSSFreq(*p.CTCSSFreq)
+	}
+	if p.DCSCode != nil {
+		target.SetDCSCode(*p.DCSCode)
+	}
+
+	if p.RxFreq != nil || p.RxStep != nil {
+		freq, err := CheckStep(target.GetRxFreq(), target.GetRxStep(), p.Snap)
+		if err != nil {
+			return err
+		}
+		target.SetRxFreq(freq)
+	}
+
+	if !isChannel {
+		return nil
+	}
+
+	if p.TxFreq != nil {
+		channel.TxFreq = *p.TxFreq
+	}
+	if p.TxStep != nil {
+		channel.TxStep = *p.TxStep
+	}
+	if p.Lockout != nil {
+		channel.Lockout = boolCode(*p.Lockout)
+	}
+	if p.Name != nil {
+		channel.Name = *p.Name
+	}
+
+	if channel.TxFreq != 0 && (p.TxFreq != nil || p.TxStep != nil) {
+		freq, err := CheckStep(channel.TxFreq, channel.TxStep, p.Snap)
+		if err != nil {
+			return fmt.Errorf("transmit frequency: %w", err)
+		}
+		channel.TxFreq = freq
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package types
+
+import (
+	"testing"
+
+	flag "github.com/spf13/pflag"
+)
+
+func TestParseSettingsPatch(t *testing.T) {
+	p, err := ParseSettingsPatch([]byte(`{"rxFreq":"146.820","shift":"down","offset":"600k","toneMode":"tone","toneFreq":"146.2","mode":null}`))
+	if err != nil {
+		t.Fatalf("ParseSettingsPatch() failed: %v", err)
+	}
+
+	vfo := VFO{RxFreq: 146520000, Mode: 1, ToneFreq: 8, CTCSSFreq: 8}
+	if err := p.Apply(&vfo); err != nil {
+		t.Fatalf("Apply() failed: %v", err)
+	}
+
+	// A null member resets the setting to its zero value
+	expected := VFO{RxFreq: 146820000, Shift: 2, Tone: 1, ToneFreq: 23, CTCSSFreq: 8, Offset: 600000, Mode: 0}
+	if vfo != expected {
+		t.Errorf("have %+v, expected %+v", vfo, expected)
+	}
+
+	for _, doc := range []string{
+		`{"shift":"sideways"}`,
+		`{"toneMode":"ctcss"}`,
+		`{"rxFreq":"146.52GHz"}`,
+		`{"frequency":"146.52"}`,
+		`{"rxFreq":null}`,
+		`[]`,
+	} {
+		if _, err := ParseSettingsPatch([]byte(doc)); err == nil {
+			t.Errorf("expected error parsing %s", doc)
+		}
+	}
+}
+
+func TestSettingsPatchChannelFields(t *testing.T) {
+	p, err := ParseSettingsPatch([]byte(`{"name":"BAKBAY","lockout":true,"txFreq":"147.42"}`))
+	if err != nil {
+		t.Fatalf("ParseSettingsPatch() failed: %v", err)
+	}
+
+	channel := Channel{Number: 90, RxFreq: 146820000}
+	if err := p.Apply(&channel); err != nil {
+		t.Fatalf("Apply() failed: %v", err)
+	}
+	if channel.Name != "BAKBAY" || channel.Lockout != 1 || channel.TxFreq != 147420000 || channel.RxFreq != 146820000 {
+		t.Errorf("unexpected channel: %+v", channel)
+	}
+
+	vfo := VFO{}
+	if err := p.Apply(&vfo); err == nil {
+		t.Errorf("expected error applying channel fields to a vfo")
+	}
+}
+
+func TestSettingsPatchNull(t *testing.T) {
+	p, err := ParseSettingsPatch([]byte(`{"name":null,"txFreq":null,"toneMode":null,"lockout":null,"ctcssFreq":null}`))
+	if err != nil {
+		t.Fatalf("ParseSettingsPatch() failed: %v", err)
+	}
+	if p.RxStep != nil || p.Shift != nil {
+		t.Errorf("absent members should not be in the patch: %+v", p)
+	}
+
+	channel := Channel{Number: 90, Name: "BAKBAY", RxFreq: 146820000, TxFreq: 147420000, Tone: 1, CTCSS: 1, ToneFreq: 23, CTCSSFreq: 23, Lockout: 1}
+	if err := p.Apply(&channel); err != nil {
+		t.Fatalf("Apply() failed: %v", err)
+	}
+
+	expected := Channel{Number: 90, RxFreq: 146820000, ToneFreq: 23}
+	if channel != expected {
+		t.Errorf("have %+v, expected %+v", channel, expected)
+	}
+}
+
+func TestSettingsPatchStep(t *testing.T) {
+	freq := 146521000
+	vfo := VFO{RxFreq: 146520000}
+
+	p := SettingsPatch{RxFreq: &freq}
+	if err := p.Apply(&vfo); err == nil {
+		t.Errorf("expected error for off-grid frequency")
+	}
+
+	p.Snap = true
+	if err := p.Apply(&vfo); err != nil {
+		t.Fatalf("Apply() failed: %v", err)
+	}
+	if vfo.RxFreq != 146520000 {
+		t.Errorf("RxFreq = %d, expected 146520000", vfo.RxFreq)
+	}
+}
+
+func TestRadioSettingsPatch(t *testing.T) {
+	var values RadioFlagValues
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddRadioSettingFlags(flags, &values)
+
+	if err := flags.Parse([]string{"--rxfreq", "146.82", "--tone-mode", "tsql", "--no-reverse"}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	p := RadioSettingsPatch(flags, &values)
+	if p.RxFreq == nil || *p.RxFreq != 146820000 {
+		t.Errorf("unexpected RxFreq: %v", p.RxFreq)
+	}
+	if p.ToneMode == nil || *p.ToneMode != TONE_MODE_TSQL {
+		t.Errorf("unexpected ToneMode: %v", p.ToneMode)
+	}
+	if p.Reverse == nil || *p.Reverse {
+		t.Errorf("unexpected Reverse: %v", p.Reverse)
+	}
+	if p.Mode != nil || p.Shift != nil || p.Offset != nil || p.ToneFreq != nil {
+		t.Errorf("unset flags should not be in the patch: %+v", p)
+	}
+}
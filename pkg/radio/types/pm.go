@@ -0,0 +1,41 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type (
+	// PM selects one of the radio's programmable memory slots, which store
+	// a complete radio configuration. PM_OFF means no slot is active.
+	PM int
+)
+
+const (
+	PM_OFF PM = 0
+	PM_MAX PM = 5
+)
+
+func (p PM) String() string {
+	switch {
+	case p == PM_OFF:
+		return "off"
+	case p > PM_OFF && p <= PM_MAX:
+		return fmt.Sprintf("%d", p)
+	default:
+		return "<invalid>"
+	}
+}
+
+func ParsePM(s string) (PM, error) {
+	if s == "off" {
+		return PM_OFF, nil
+	}
+
+	val, err := strconv.Atoi(s)
+	if err != nil || val < 1 || PM(val) > PM_MAX {
+		return 0, fmt.Errorf("invalid programmable memory: %s", s)
+	}
+
+	return PM(val), nil
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestParsePM(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected PM
+		valid    bool
+	}{
+		{"off", PM_OFF, true},
+		{"1", 1, true},
+		{"5", 5, true},
+		{"0", 0, false},
+		{"6", 0, false},
+		{"on", 0, false},
+	}
+
+	for _, tt := range tests {
+		have, err := ParsePM(tt.input)
+		if err != nil && tt.valid {
+			t.Errorf("%s: expected success, failed with: %v", tt.input, err)
+		} else if err == nil && !tt.valid {
+			t.Errorf("%s: expected error", tt.input)
+		}
+
+		if tt.valid {
+			if have != tt.expected {
+				t.Errorf("%s: have %d, expected %d", tt.input, have, tt.expected)
+			}
+			if have.String() != tt.input {
+				t.Errorf("%s: String() returned %s", tt.input, have.String())
+			}
+		}
+	}
+}
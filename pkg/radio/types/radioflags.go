@@ -1,6 +1,8 @@
 package types
 
 import (
+	"fmt"
+
 	flag "github.com/spf13/pflag"
 )
 
@@ -16,6 +18,8 @@ type RadioFlagValues struct {
 	ToneFreq  int
 	CTCSSFreq int
 	DCSCode   int
+	ToneMode  ToneMode
+	Snap      bool
 }
 
 // AddRadioSettingFlags adds common radio setting flags to the provided FlagSet.
@@ -28,56 +32,66 @@ func AddRadioSettingFlags(flags *flag.FlagSet, values *RadioFlagValues) {
 	flags.Bool("reverse", false, "reverse tx/rx")
 	flags.Bool("no-reverse", false, "disable reverse tx/rx")
 	flags.VarP(NewFrequencyMHz(&values.Offset), "offset", "o", "offset in MHz (e.g., 0.6)")
-	flags.StringP("tone-mode", "t", "none", "select tone mode (none, tone, tsql, dcs)")
+	flags.VarP(&values.ToneMode, "tone-mode", "t", "select tone mode (none, tone, tsql, dcs)")
 	flags.VarP(NewTone(&values.ToneFreq), "txtone", "", "CTCSS tone when sending")
 	flags.VarP(NewTone(&values.CTCSSFreq), "rxtone", "", "CTCSS tone when receiving")
 	flags.VarP(NewDCS(&values.DCSCode), "dcs", "", "DCS code")
+	flags.BoolVarP(&values.Snap, "snap", "", false, "round frequencies to the nearest step instead of rejecting them")
 }
 
-// ApplyRadioSettingFlags applies only the flags that were set (visited) to the target.
-// This ensures that unset flags don't overwrite existing values in the target.
-func ApplyRadioSettingFlags(flags *flag.FlagSet, values *RadioFlagValues, target RadioSettable) {
+// CheckStep verifies that freq (in Hz) is a multiple of the given step code.
+// If snap is true, an off-grid frequency is rounded to the nearest step
+// instead of being rejected.
+func CheckStep(freq int, step int, snap bool) (int, error) {
+	f := Frequency(freq)
+	if f.OnGrid(step) {
+		return freq, nil
+	}
+	if snap {
+		return int(f.Snap(step)), nil
+	}
+	return freq, fmt.Errorf("frequency %s MHz is not a multiple of the %s kHz step (use --snap to round it)", f, NewStepSize(&step))
+}
+
+// RadioSettingsPatch returns a SettingsPatch containing only the flags that
+// were set (visited), so that unset flags don't overwrite existing values.
+func RadioSettingsPatch(flags *flag.FlagSet, values *RadioFlagValues) SettingsPatch {
+	p := SettingsPatch{Snap: values.Snap}
 	flags.Visit(func(f *flag.Flag) {
 		switch f.Name {
 		case "rxfreq":
-			target.SetRxFreq(values.RxFreq)
+			p.RxFreq = ptr(values.RxFreq)
 		case "rxstep":
-			target.SetRxStep(values.RxStep)
+			p.RxStep = ptr(values.RxStep)
 		case "mode":
-			target.SetMode(values.Mode)
+			p.Mode = ptr(values.Mode)
 		case "shift":
-			target.SetShift(values.Shift)
+			p.Shift = ptr(values.Shift)
 		case "reverse":
-			target.SetReverse(1)
+			p.Reverse = ptr(true)
 		case "no-reverse":
-			target.SetReverse(0)
+			p.Reverse = ptr(false)
 		case "offset":
-			target.SetOffset(values.Offset)
+			p.Offset = ptr(values.Offset)
 		case "tone-mode":
-			switch f.Value.String() {
-			case "none":
-				target.SetTone(0)
-				target.SetCTCSS(0)
-				target.SetDCS(0)
-			case "tone":
-				target.SetTone(1)
-				target.SetCTCSS(0)
-				target.SetDCS(0)
-			case "tsql":
-				target.SetTone(1)
-				target.SetCTCSS(1)
-				target.SetDCS(0)
-			case "dcs":
-				target.SetTone(0)
-				target.SetCTCSS(0)
-				target.SetDCS(1)
-			}
+			p.ToneMode = ptr(values.ToneMode)
 		case "txtone":
-			target.SetToneFreq(values.ToneFreq)
+			p.ToneFreq = ptr(values.ToneFreq)
 		case "rxtone":
-			target.SetCTCSSFreq(values.CTCSSFreq)
+			p.CTCSSFreq = ptr(values.CTCSSFreq)
 		case "dcs":
-			target.SetDCSCode(values.DCSCode)
+			p.DCSCode = ptr(values.DCSCode)
 		}
 	})
+	return p
+}
+
+// ApplyRadioSettingFlags applies only the flags that were set (visited) to the target.
+// This ensures that unset flags don't overwrite existing values in the target.
+func ApplyRadioSettingFlags(flags *flag.FlagSet, values *RadioFlagValues, target RadioSettable) error {
+	return RadioSettingsPatch(flags, values).Apply(target)
+}
+
+func ptr[T any](v T) *T {
+	return &v
 }
@@ -40,3 +40,27 @@ type RadioSettable interface {
 	GetDCSCode() int
 	SetDCSCode(int)
 }
+
+// TxFrequency returns the frequency (in Hz) on which s transmits, taking
+// shift, offset and reverse into account. If s has an explicit transmit
+// frequency (an odd split channel), that frequency is used.
+func TxFrequency(s RadioSettable) int {
+	rx := s.GetRxFreq()
+
+	if split, ok := s.(interface{ GetTxFreq() int }); ok && split.GetTxFreq() != 0 {
+		return split.GetTxFreq()
+	}
+
+	if s.GetReverse() != 0 {
+		return rx
+	}
+
+	switch s.GetShift() {
+	case 1:
+		return rx + s.GetOffset()
+	case 2:
+		return rx - s.GetOffset()
+	default:
+		return rx
+	}
+}
@@ -47,3 +47,23 @@ func TestVFOImplementsRadioSettable(t *testing.T) {
 		t.Errorf("GetCTCSS() = %d, expected 1", v.GetCTCSS())
 	}
 }
+
+func TestTxFrequency(t *testing.T) {
+	tests := []struct {
+		name     string
+		settable RadioSettable
+		expected int
+	}{
+		{"simplex", &VFO{RxFreq: 146520000}, 146520000},
+		{"shift up", &VFO{RxFreq: 146520000, Shift: 1, Offset: 600000}, 147120000},
+		{"shift down", &Channel{RxFreq: 146820000, Shift: 2, Offset: 600000}, 146220000},
+		{"reverse", &Channel{RxFreq: 146820000, Shift: 2, Offset: 600000, Reverse: 1}, 146820000},
+		{"odd split", &Channel{RxFreq: 146820000, TxFreq: 147000000}, 147000000},
+	}
+
+	for _, tt := range tests {
+		if have := TxFrequency(tt.settable); have != tt.expected {
+			t.Errorf("%s: TxFrequency() = %d, expected %d", tt.name, have, tt.expected)
+		}
+	}
+}
@@ -47,3 +47,13 @@ func (m *Shift) Set(value string) error {
 func (m *Shift) Type() string {
 	return "shift"
 }
+
+// MarshalText implements encoding.TextMarshaler.
+func (m *Shift) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *Shift) UnmarshalText(text []byte) error {
+	return m.Set(string(text))
+}
@@ -16,5 +16,6 @@ type (
 		PttVfo   int
 		CtlVfo   int
 		BandMode string
+		PM       string
 	}
 )
@@ -55,3 +55,52 @@ func (f *StepSize) Set(value string) error {
 func (f *StepSize) Type() string {
 	return "stepSize"
 }
+
+// MarshalText implements encoding.TextMarshaler.
+func (f *StepSize) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *StepSize) UnmarshalText(text []byte) error {
+	return f.Set(string(text))
+}
+
+// stepSizeHz gives the size of each step code as a fraction of Hz, so that
+// the 8.33 kHz airband step can be represented exactly.
+var stepSizeHz = map[int]struct{ num, den int }{
+	0x0: {5_000, 1},
+	0x1: {6_250, 1},
+	0x2: {25_000, 3},
+	0x3: {10_000, 1},
+	0x4: {12_500, 1},
+	0x5: {15_000, 1},
+	0x6: {20_000, 1},
+	0x7: {25_000, 1},
+	0x8: {30_000, 1},
+	0x9: {50_000, 1},
+	0xA: {100_000, 1},
+}
+
+// Snap returns the frequency rounded to the nearest multiple of the given
+// step code. Unknown step codes return the frequency unchanged.
+func (f Frequency) Snap(step int) Frequency {
+	return f.AddSteps(0, step)
+}
+
+// AddSteps aligns the frequency to the grid of the given step code and then
+// moves it by n steps (which may be negative). Unknown step codes return the
+// frequency unchanged.
+func (f Frequency) AddSteps(n int, step int) Frequency {
+	size, exists := stepSizeHz[step]
+	if !exists {
+		return f
+	}
+	steps := (int(f)*size.den+size.num/2)/size.num + n
+	return Frequency((steps*size.num + size.den/2) / size.den)
+}
+
+// OnGrid reports whether the frequency is a multiple of the given step code.
+func (f Frequency) OnGrid(step int) bool {
+	return f.Snap(step) == f
+}
@@ -131,3 +131,13 @@ func (t *Tone) Set(value string) error {
 func (t *Tone) Type() string {
 	return "tone"
 }
+
+// MarshalText implements encoding.TextMarshaler.
+func (t *Tone) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *Tone) UnmarshalText(text []byte) error {
+	return t.Set(string(text))
+}
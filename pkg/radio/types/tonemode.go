@@ -0,0 +1,131 @@
+package types
+
+import (
+	"fmt"
+)
+
+type (
+	// ToneMode combines the Tone, CTCSS and DCS status fields into a
+	// single setting. The radio does not support cross modes (such as
+	// a CTCSS tone on transmit and DCS on receive), so only the
+	// combinations below are valid.
+	ToneMode int
+)
+
+const (
+	TONE_MODE_INVALID ToneMode = -1
+	TONE_MODE_NONE    ToneMode = 0
+	TONE_MODE_TONE    ToneMode = 1
+	TONE_MODE_TSQL    ToneMode = 2
+	TONE_MODE_DCS     ToneMode = 3
+)
+
+var (
+	toneModeNames = map[string]ToneMode{
+		"none": TONE_MODE_NONE,
+		"tone": TONE_MODE_TONE,
+		"tsql": TONE_MODE_TSQL,
+		"dcs":  TONE_MODE_DCS,
+	}
+
+	// toneModeFlags gives the values of the Tone, CTCSS and DCS
+	// fields written for each tone mode. TSQL is written as CTCSS
+	// alone, as chirp's TM-V71 driver does.
+	toneModeFlags = map[ToneMode][3]int{
+		TONE_MODE_NONE: {0, 0, 0},
+		TONE_MODE_TONE: {1, 0, 0},
+		TONE_MODE_TSQL: {0, 1, 0},
+		TONE_MODE_DCS:  {0, 0, 1},
+	}
+
+	// toneModeAliases lists other combinations that are read as a tone
+	// mode: TSQL with the Tone field also set.
+	toneModeAliases = map[[3]int]ToneMode{
+		{1, 1, 0}: TONE_MODE_TSQL,
+	}
+)
+
+func (m ToneMode) String() string {
+	switch m {
+	case TONE_MODE_NONE:
+		return "none"
+	case TONE_MODE_TONE:
+		return "tone"
+	case TONE_MODE_TSQL:
+		return "tsql"
+	case TONE_MODE_DCS:
+		return "dcs"
+	default:
+		return "<invalid>"
+	}
+}
+
+func ParseToneMode(s string) (ToneMode, error) {
+	if val, exists := toneModeNames[s]; exists {
+		return val, nil
+	}
+
+	return TONE_MODE_INVALID, fmt.Errorf("invalid tone mode: %s", s)
+}
+
+// GetToneMode derives the tone mode from the Tone, CTCSS and DCS fields of
+// s. TSQL is accepted both with and without the Tone field set.
+// Combinations that do not correspond to a tone mode (such as CTCSS with
+// DCS) return TONE_MODE_INVALID.
+func GetToneMode(s RadioSettable) ToneMode {
+	have := [3]int{s.GetTone(), s.GetCTCSS(), s.GetDCS()}
+	for mode, flags := range toneModeFlags {
+		if have == flags {
+			return mode
+		}
+	}
+	if mode, exists := toneModeAliases[have]; exists {
+		return mode
+	}
+	return TONE_MODE_INVALID
+}
+
+// SetToneMode writes the tone mode back to the Tone, CTCSS and DCS fields
+// of s. If s is already in that mode, its fields are left as they are.
+func SetToneMode(s RadioSettable, m ToneMode) error {
+	flags, exists := toneModeFlags[m]
+	if !exists {
+		return fmt.Errorf("invalid tone mode: %d", m)
+	}
+	if GetToneMode(s) == m {
+		return nil
+	}
+	s.SetTone(flags[0])
+	s.SetCTCSS(flags[1])
+	s.SetDCS(flags[2])
+	return nil
+}
+
+// Set parses a tone mode name, so that *ToneMode can be used as a flag
+func (m *ToneMode) Set(value string) error {
+	val, err := ParseToneMode(value)
+	if err != nil {
+		return err
+	}
+	*m = val
+	return nil
+}
+
+// Type returns the type name for help text
+func (m *ToneMode) Type() string {
+	return "toneMode"
+}
+
+// MarshalText implements encoding.TextMarshaler. TONE_MODE_INVALID has
+// no text form, since it could not be parsed again.
+func (m ToneMode) MarshalText() ([]byte, error) {
+	if _, exists := toneModeFlags[m]; !exists {
+		return nil, fmt.Errorf("invalid tone mode: %d", m)
+	}
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *ToneMode) UnmarshalText(text []byte) error {
+	return m.Set(string(text))
+}
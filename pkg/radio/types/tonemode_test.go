@@ -0,0 +1,83 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestParseToneMode(t *testing.T) {
+	for _, name := range []string{"none", "tone", "tsql", "dcs"} {
+		mode, err := ParseToneMode(name)
+		if err != nil {
+			t.Errorf("ParseToneMode(%q) failed: %v", name, err)
+		}
+		if mode.String() != name {
+			t.Errorf("ParseToneMode(%q).String() = %s", name, mode)
+		}
+	}
+
+	if _, err := ParseToneMode("ctcss"); err == nil {
+		t.Errorf("expected error for invalid tone mode")
+	}
+}
+
+func TestToneModeRoundTrip(t *testing.T) {
+	for _, mode := range []ToneMode{TONE_MODE_NONE, TONE_MODE_TONE, TONE_MODE_TSQL, TONE_MODE_DCS} {
+		v := VFO{Tone: 1, CTCSS: 1, DCS: 1}
+		if err := SetToneMode(&v, mode); err != nil {
+			t.Fatalf("SetToneMode(%s) failed: %v", mode, err)
+		}
+		if have := GetToneMode(&v); have != mode {
+			t.Errorf("GetToneMode() = %s, expected %s", have, mode)
+		}
+	}
+
+	if err := SetToneMode(&VFO{}, TONE_MODE_INVALID); err == nil {
+		t.Errorf("expected error setting invalid tone mode")
+	}
+}
+
+func TestGetToneModeInvalid(t *testing.T) {
+	tests := []struct {
+		name             string
+		tone, ctcss, dcs int
+	}{
+		{"ctcss with dcs", 0, 1, 1},
+		{"dcs with tone", 1, 0, 1},
+		{"everything", 1, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Channel{Tone: tt.tone, CTCSS: tt.ctcss, DCS: tt.dcs}
+			if mode := GetToneMode(&c); mode != TONE_MODE_INVALID {
+				t.Errorf("GetToneMode() = %s, expected %s", mode, TONE_MODE_INVALID)
+			}
+		})
+	}
+}
+
+func TestToneModeTSQLEncodings(t *testing.T) {
+	// The radio may report TSQL with or without the Tone field set
+	for _, c := range []Channel{{CTCSS: 1}, {Tone: 1, CTCSS: 1}} {
+		if mode := GetToneMode(&c); mode != TONE_MODE_TSQL {
+			t.Errorf("GetToneMode(%+v) = %s, expected tsql", c, mode)
+		}
+
+		// Setting the mode a channel already has leaves its fields alone
+		have := c
+		if err := SetToneMode(&have, TONE_MODE_TSQL); err != nil {
+			t.Fatalf("SetToneMode() failed: %v", err)
+		}
+		if have != c {
+			t.Errorf("SetToneMode() changed %+v to %+v", c, have)
+		}
+	}
+
+	v := VFO{Tone: 1}
+	if err := SetToneMode(&v, TONE_MODE_TSQL); err != nil {
+		t.Fatalf("SetToneMode() failed: %v", err)
+	}
+	if v.Tone != 0 || v.CTCSS != 1 || v.DCS != 0 {
+		t.Errorf("unexpected tsql fields: %+v", v)
+	}
+}
@@ -1,7 +1,9 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 )
@@ -29,9 +31,7 @@ type (
 		RxStep    string
 		Shift     string
 		Reverse   string
-		Tone      string
-		CTCSS     string
-		DCS       string
+		ToneMode  string
 		ToneFreq  string
 		CTCSSFreq string
 		DCSCode   string
@@ -58,6 +58,14 @@ type (
 
 var EmptyVFO = VFO{}
 
+const (
+	// MaxOffset is the largest transmit offset (in Hz) the radio supports.
+	MaxOffset = 29_950_000
+
+	// OffsetStep is the resolution (in Hz) of the transmit offset.
+	OffsetStep = 50_000
+)
+
 // FO 1,0145090000,0,0,0,0,0,0,08,08,000,00000000,0
 func ParseVFO(s string) (VFO, error) {
 	parts := []int{}
@@ -108,24 +116,30 @@ func (v VFO) Serialize() string {
 	)
 }
 
+// Produce a row suitable for table formatting, with the same columns as
+// DisplayVFO
 func (v VFO) Values() []string {
+	d := v.Display()
 	return []string{
-		fmt.Sprintf("%d", v.VFO),
-		NewFrequencyMHz(&v.RxFreq).String(),
-		NewStepSize(&v.RxStep).String(),
-		NewShift(&v.Shift).String(),
-		NewBool(&v.Reverse).String(),
-		NewBool(&v.Tone).String(),
-		NewBool(&v.CTCSS).String(),
-		NewBool(&v.DCS).String(),
-		NewTone(&v.ToneFreq).String(),
-		NewTone(&v.CTCSSFreq).String(),
-		NewDCS(&v.DCSCode).String(),
-		NewFrequencyMHz(&v.Offset).String(),
-		NewMode(&v.Mode).String(),
+		fmt.Sprintf("%d", d.VFO),
+		d.RxFreq,
+		d.RxStep,
+		d.Shift,
+		d.Reverse,
+		d.ToneMode,
+		d.ToneFreq,
+		d.CTCSSFreq,
+		d.DCSCode,
+		d.Offset,
+		d.Mode,
 	}
 }
 
+// Diff returns the fields that differ between v and other, in field order.
+func (v VFO) Diff(other VFO) []FieldDiff {
+	return diffValues(reflect.TypeOf(DisplayVFO{}), v.Values(), other.Values())
+}
+
 func (v VFO) Display() DisplayVFO {
 	return DisplayVFO{
 		VFO:       v.VFO,
@@ -133,9 +147,7 @@ func (v VFO) Display() DisplayVFO {
 		RxStep:    NewStepSize(&v.RxStep).String(),
 		Shift:     NewShift(&v.Shift).String(),
 		Reverse:   NewBool(&v.Reverse).String(),
-		Tone:      NewBool(&v.Tone).String(),
-		CTCSS:     NewBool(&v.CTCSS).String(),
-		DCS:       NewBool(&v.DCS).String(),
+		ToneMode:  GetToneMode(&v).String(),
 		ToneFreq:  NewTone(&v.ToneFreq).String(),
 		CTCSSFreq: NewTone(&v.CTCSSFreq).String(),
 		DCSCode:   NewDCS(&v.DCSCode).String(),
@@ -144,22 +156,65 @@ func (v VFO) Display() DisplayVFO {
 	}
 }
 
+// String returns the human-friendly values of v as a JSON object.
 func (v VFO) String() string {
-	return strings.Join([]string{
-		fmt.Sprintf("%d", v.VFO),
-		NewFrequencyMHz(&v.RxFreq).String(),
-		NewStepSize(&v.RxStep).String(),
-		NewShift(&v.Shift).String(),
-		NewBool(&v.Reverse).String(),
-		NewBool(&v.Tone).String(),
-		NewBool(&v.CTCSS).String(),
-		NewBool(&v.DCS).String(),
-		NewTone(&v.ToneFreq).String(),
-		NewTone(&v.CTCSSFreq).String(),
-		NewDCS(&v.DCSCode).String(),
-		NewFrequencyMHz(&v.Offset).String(),
-		NewMode(&v.Mode).String(),
-	}, ",")
+	data, err := json.Marshal(v.Display())
+	if err != nil {
+		return fmt.Sprintf("%#v", v)
+	}
+	return string(data)
+}
+
+// ToChannel converts v into a memory channel with the given number and
+// name. The transmit step is the same as the receive step.
+func (v VFO) ToChannel(number int, name string) Channel {
+	return Channel{
+		Name:      name,
+		Number:    number,
+		RxFreq:    v.RxFreq,
+		RxStep:    v.RxStep,
+		Shift:     v.Shift,
+		Reverse:   v.Reverse,
+		Tone:      v.Tone,
+		CTCSS:     v.CTCSS,
+		DCS:       v.DCS,
+		ToneFreq:  v.ToneFreq,
+		CTCSSFreq: v.CTCSSFreq,
+		DCSCode:   v.DCSCode,
+		Offset:    v.Offset,
+		Mode:      v.Mode,
+		TxStep:    v.RxStep,
+	}
+}
+
+// SetSplit configures v to transmit on txFreq (in Hz) by setting the shift
+// and offset. The VFO has no separate transmit frequency, so the split must
+// be reachable with an offset of at most MaxOffset in steps of OffsetStep.
+func (v *VFO) SetSplit(txFreq int) error {
+	offset := txFreq - v.RxFreq
+	shift := 1
+	if offset < 0 {
+		offset = -offset
+		shift = 2
+	}
+
+	if offset > MaxOffset {
+		return fmt.Errorf("cannot transmit on %s MHz: offset from %s MHz is more than %s MHz",
+			Frequency(txFreq), Frequency(v.RxFreq), Frequency(MaxOffset).Compact())
+	}
+	if offset%OffsetStep != 0 {
+		return fmt.Errorf("cannot transmit on %s MHz: offset from %s MHz is not a multiple of %d kHz",
+			Frequency(txFreq), Frequency(v.RxFreq), OffsetStep/1000)
+	}
+
+	if offset == 0 {
+		shift = 0
+	} else {
+		v.Offset = offset
+	}
+	v.Shift = shift
+	v.Reverse = 0
+	return nil
 }
 
 // RadioSettable interface implementation
@@ -63,57 +63,62 @@ func TestVFO_MarshalJSON(t *testing.T) {
 			name: "standard VFO configuration",
 			vfo:  VFO{1, 145090000, 0, 0, 0, 0, 0, 0, 8, 8, 0, 0, 0},
 			expected: map[string]any{
-				"VFO":       float64(1),
-				"RxFreq":    "145.090000",
-				"RxStep":    "5",
-				"Shift":     float64(0),
-				"Reverse":   float64(0),
-				"Tone":      float64(0),
-				"CTCSS":     float64(0),
-				"DCS":       float64(0),
-				"ToneFreq":  float64(8),
-				"CTCSSFreq": float64(8),
-				"DCSFreq":   float64(0),
-				"Offset":    "0.000000",
-				"Mode":      "FM",
+				"vfo":       float64(1),
+				"rxFreq":    "145.090",
+				"rxStep":    "5",
+				"shift":     "simplex",
+				"reverse":   false,
+				"toneMode":  "none",
+				"toneFreq":  "88.5",
+				"ctcssFreq": "88.5",
+				"dcsCode":   "023",
+				"offset":    "0.000",
+				"mode":      "FM",
 			},
 		},
 		{
 			name: "UHF with offset and NFM mode",
 			vfo:  VFO{0, 446500000, 4, 1, 0, 0, 0, 0, 8, 8, 0, 5000000, 1},
 			expected: map[string]any{
-				"VFO":       float64(0),
-				"RxFreq":    "446.500000",
-				"RxStep":    "12.5",
-				"Shift":     float64(1),
-				"Reverse":   float64(0),
-				"Tone":      float64(0),
-				"CTCSS":     float64(0),
-				"DCS":       float64(0),
-				"ToneFreq":  float64(8),
-				"CTCSSFreq": float64(8),
-				"DCSFreq":   float64(0),
-				"Offset":    "5.000000",
-				"Mode":      "NFM",
+				"vfo":       float64(0),
+				"rxFreq":    "446.500",
+				"rxStep":    "12.5",
+				"shift":     "up",
+				"reverse":   false,
+				"toneMode":  "none",
+				"toneFreq":  "88.5",
+				"ctcssFreq": "88.5",
+				"dcsCode":   "023",
+				"offset":    "5.000",
+				"mode":      "NFM",
 			},
 		},
 		{
 			name: "AM mode with different step size",
 			vfo:  VFO{1, 118000000, 7, 0, 0, 0, 0, 0, 8, 8, 0, 0, 2},
 			expected: map[string]any{
-				"VFO":       float64(1),
-				"RxFreq":    "118.000000",
-				"RxStep":    "25",
-				"Shift":     float64(0),
-				"Reverse":   float64(0),
-				"Tone":      float64(0),
-				"CTCSS":     float64(0),
-				"DCS":       float64(0),
-				"ToneFreq":  float64(8),
-				"CTCSSFreq": float64(8),
-				"DCSFreq":   float64(0),
-				"Offset":    "0.000000",
-				"Mode":      "AM",
+				"vfo":       float64(1),
+				"rxFreq":    "118.000",
+				"rxStep":    "25",
+				"shift":     "simplex",
+				"reverse":   false,
+				"toneMode":  "none",
+				"toneFreq":  "88.5",
+				"ctcssFreq": "88.5",
+				"dcsCode":   "023",
+				"offset":    "0.000",
+				"mode":      "AM",
+			},
+		},
+		{
+			name: "6.25 kHz channel with tone",
+			vfo:  VFO{0, 446006250, 1, 2, 0, 1, 0, 0, 23, 8, 0, 5000000, 1},
+			expected: map[string]any{
+				"rxFreq":   "446.00625",
+				"rxStep":   "6.25",
+				"shift":    "down",
+				"toneMode": "tone",
+				"toneFreq": "146.2",
 			},
 		},
 	}
@@ -170,3 +175,107 @@ func TestVFO_String(t *testing.T) {
 		t.Errorf("RxStep not human-friendly: got %v", parsed["RxStep"])
 	}
 }
+
+func TestVFODiff(t *testing.T) {
+	old := VFO{VFO: 0, RxFreq: 146520000, ToneFreq: 8, CTCSSFreq: 8}
+	new := old
+	new.RxFreq = 146525000
+
+	diffs := old.Diff(new)
+	if len(diffs) != 1 || diffs[0].Field != "RxFreq" {
+		t.Errorf("unexpected differences: %v", diffs)
+	}
+}
+
+func TestVFO_UnmarshalJSON(t *testing.T) {
+	var vfo VFO
+	err := json.Unmarshal([]byte(`{"vfo":0,"rxFreq":"146.820","shift":"down","offset":"600k","toneMode":"tone","toneFreq":"146.2","mode":"FM"}`), &vfo)
+	if err != nil {
+		t.Fatalf("UnmarshalJSON() failed: %v", err)
+	}
+
+	expected := VFO{VFO: 0, RxFreq: 146820000, Shift: 2, Tone: 1, ToneFreq: 23, Offset: 600000}
+	if vfo != expected {
+		t.Errorf("have %+v, expected %+v", vfo, expected)
+	}
+
+	for _, doc := range []string{
+		`{"shift":"sideways"}`,
+		`{"toneFreq":"146.3"}`,
+		`{"rxFreq":"146.52GHz"}`,
+		`{"mode":"USB"}`,
+		`{"toneMode":"cross"}`,
+	} {
+		if err := json.Unmarshal([]byte(doc), &vfo); err == nil {
+			t.Errorf("expected error decoding %s", doc)
+		}
+	}
+}
+
+func TestVFOSetSplit(t *testing.T) {
+	tests := []struct {
+		name    string
+		rxFreq  int
+		txFreq  int
+		shift   int
+		offset  int
+		wantErr bool
+	}{
+		{"standard 2m repeater", 146820000, 146220000, 2, 600000, false},
+		{"positive offset", 147000000, 147600000, 1, 600000, false},
+		{"odd split", 145150000, 146950000, 1, 1800000, false},
+		{"simplex", 146520000, 146520000, 0, 5000000, false},
+		{"offset too large", 146520000, 446520000, 0, 0, true},
+		{"offset not on grid", 146940000, 147555000, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := VFO{RxFreq: tt.rxFreq, Reverse: 1, Offset: 5000000}
+			err := v.SetSplit(tt.txFreq)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetSplit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if v.Shift != tt.shift || v.Offset != tt.offset || v.Reverse != 0 {
+				t.Errorf("have shift %d offset %d reverse %d, expected shift %d offset %d reverse 0", v.Shift, v.Offset, v.Reverse, tt.shift, tt.offset)
+			}
+			if TxFrequency(&v) != tt.txFreq {
+				t.Errorf("TxFrequency() = %d, expected %d", TxFrequency(&v), tt.txFreq)
+			}
+		})
+	}
+}
+
+func TestVFOChannelConversion(t *testing.T) {
+	vfo := VFO{VFO: 1, RxFreq: 146820000, Shift: 2, Tone: 1, ToneFreq: 23, CTCSSFreq: 8, Offset: 600000, RxStep: 1}
+
+	channel := vfo.ToChannel(90, "BAKBAY")
+	if channel.Number != 90 || channel.Name != "BAKBAY" || channel.TxStep != vfo.RxStep || channel.TxFreq != 0 {
+		t.Errorf("unexpected channel: %+v", channel)
+	}
+
+	back, err := channel.ToVFO(1)
+	if err != nil {
+		t.Fatalf("ToVFO() failed: %v", err)
+	}
+	if back != vfo {
+		t.Errorf("round trip: have %+v, expected %+v", back, vfo)
+	}
+
+	channel.TxFreq = 147420000
+	split, err := channel.ToVFO(0)
+	if err != nil {
+		t.Fatalf("ToVFO() with split failed: %v", err)
+	}
+	if TxFrequency(&split) != 147420000 {
+		t.Errorf("TxFrequency() = %d, expected 147420000", TxFrequency(&split))
+	}
+
+	channel.TxFreq = 446000000
+	if _, err := channel.ToVFO(0); err == nil {
+		t.Errorf("expected error for unreachable split")
+	}
+}
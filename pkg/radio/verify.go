@@ -0,0 +1,99 @@
+package radio
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+type (
+	// VerifyMode controls whether writes are read back and compared with
+	// the values that were sent.
+	VerifyMode int
+
+	// MismatchError is returned when the radio stored different values
+	// from those that were written.
+	MismatchError struct {
+		// Target describes what was written (e.g. "channel 005").
+		Target string
+		Fields []types.FieldDiff
+	}
+)
+
+const (
+	// VerifyOff disables verification.
+	VerifyOff VerifyMode = iota
+	// VerifyStrict reports any difference as an error.
+	VerifyStrict
+	// VerifyTolerant reports differences as errors, except for names that
+	// the radio has uppercased or truncated, which are logged as warnings.
+	VerifyTolerant
+)
+
+var verifyModeNames = map[string]VerifyMode{
+	"off":      VerifyOff,
+	"strict":   VerifyStrict,
+	"tolerant": VerifyTolerant,
+}
+
+func (m VerifyMode) String() string {
+	switch m {
+	case VerifyOff:
+		return "off"
+	case VerifyStrict:
+		return "strict"
+	case VerifyTolerant:
+		return "tolerant"
+	default:
+		return "<invalid>"
+	}
+}
+
+func ParseVerifyMode(s string) (VerifyMode, error) {
+	if val, exists := verifyModeNames[s]; exists {
+		return val, nil
+	}
+
+	return 0, fmt.Errorf("invalid verify mode: %s", s)
+}
+
+func (e *MismatchError) Error() string {
+	var fields []string
+	for _, diff := range e.Fields {
+		fields = append(fields, fmt.Sprintf("%s (wrote %s, read %s)", diff.Field, diff.Old, diff.New))
+	}
+	return fmt.Sprintf("%s: radio stored different values: %s", e.Target, strings.Join(fields, ", "))
+}
+
+// WithVerify configures the radio to read back memory channels and vfos
+// after writing them.
+func (r *Radio) WithVerify(mode VerifyMode) *Radio {
+	r.verify = mode
+	return r
+}
+
+// checkWrite compares the differences between the written and stored
+// values according to the verify mode.
+func (r *Radio) checkWrite(target string, diffs []types.FieldDiff) error {
+	var mismatches []types.FieldDiff
+	for _, diff := range diffs {
+		if r.verify == VerifyTolerant && diff.Field == "Name" && isNormalizedName(diff.Old, diff.New) {
+			r.logger.Warn("radio normalized name", "target", target, "wrote", diff.Old, "read", diff.New)
+			continue
+		}
+		mismatches = append(mismatches, diff)
+	}
+
+	if len(mismatches) > 0 {
+		return &MismatchError{Target: target, Fields: mismatches}
+	}
+
+	return nil
+}
+
+// isNormalizedName returns true if stored is the result of the radio
+// uppercasing and possibly truncating written.
+func isNormalizedName(written, stored string) bool {
+	return stored != "" && strings.HasPrefix(strings.ToUpper(written), stored)
+}
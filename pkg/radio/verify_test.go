@@ -0,0 +1,86 @@
+package radio
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/larsks/kwctl/pkg/radio/types"
+)
+
+func TestCheckWrite(t *testing.T) {
+	written := types.Channel{Name: "bakbay1", Number: 90, RxFreq: 146820000}
+
+	tests := []struct {
+		name     string
+		mode     VerifyMode
+		stored   types.Channel
+		mismatch []string
+	}{
+		{
+			name:   "identical",
+			mode:   VerifyStrict,
+			stored: written,
+		},
+		{
+			name:     "normalized name in strict mode",
+			mode:     VerifyStrict,
+			stored:   types.Channel{Name: "BAKBAY", Number: 90, RxFreq: 146820000},
+			mismatch: []string{"Name"},
+		},
+		{
+			name:   "normalized name in tolerant mode",
+			mode:   VerifyTolerant,
+			stored: types.Channel{Name: "BAKBAY", Number: 90, RxFreq: 146820000},
+		},
+		{
+			name:     "different frequency in tolerant mode",
+			mode:     VerifyTolerant,
+			stored:   types.Channel{Name: "BAKBAY", Number: 90, RxFreq: 146825000},
+			mismatch: []string{"RxFreq"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRadio("/dev/null", 9600).WithVerify(tt.mode)
+			err := r.checkWrite("channel 090", written.Diff(tt.stored))
+
+			if tt.mismatch == nil {
+				if err != nil {
+					t.Errorf("expected success, failed with: %v", err)
+				}
+				return
+			}
+
+			var mismatch *MismatchError
+			if !errors.As(err, &mismatch) {
+				t.Fatalf("expected MismatchError, got %v", err)
+			}
+			if len(mismatch.Fields) != len(tt.mismatch) {
+				t.Fatalf("expected mismatches %v, got %v", tt.mismatch, mismatch.Fields)
+			}
+			for i, field := range tt.mismatch {
+				if mismatch.Fields[i].Field != field {
+					t.Errorf("expected mismatch in %s, got %s", field, mismatch.Fields[i].Field)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyWithDryRun(t *testing.T) {
+	r := NewRadio("/dev/null", 9600).WithDryRun(io.Discard).WithVerify(VerifyStrict)
+
+	if err := r.SetMemoryChannel(types.Channel{Name: "CALL", Number: 1, RxFreq: 146520000}); err != nil {
+		t.Errorf("SetMemoryChannel() failed: %v", err)
+	}
+	// Names are uppercased before they are written, so a lowercase name
+	// is not a mismatch.
+	if err := r.SetMemoryChannel(types.Channel{Name: "simplex", Number: 2, RxFreq: 146550000}); err != nil {
+		t.Errorf("SetMemoryChannel() with lowercase name failed: %v", err)
+	}
+	if err := r.SetVFO("0", types.VFO{VFO: 0, RxFreq: 146520000}); err != nil {
+		t.Errorf("SetVFO() failed: %v", err)
+	}
+}